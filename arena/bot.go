@@ -0,0 +1,85 @@
+package arena
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/rs/zerolog/log"
+)
+
+// BotPanic is the error of a bot call that panicked
+type BotPanic struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (p *BotPanic) Error() string {
+	return fmt.Sprintf("bot panicked: %v", p.Value)
+}
+
+// failingBot is implemented by bots that can break without panicking, like bots in their own process
+type failingBot interface {
+	Err() error
+}
+
+// botErr returns the error of a broken bot, nil for bots that can't break
+func botErr(ai entities.AI) error {
+	if bot, ok := ai.(failingBot); ok {
+		return bot.Err()
+	}
+	return nil
+}
+
+func safeInit(ai entities.AI, rng *rand.Rand, info entities.ArenaInfo) (name string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &BotPanic{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	ai.Init(rng, info)
+	return ai.Name(), botErr(ai)
+}
+
+func safeCompute(ai entities.AI, input entities.AIInput) (output entities.AIOutput, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &BotPanic{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	output = ai.Compute(input)
+	return output, botErr(ai)
+}
+
+// BotFactory creates the instance of a bot that takes part in a match
+type BotFactory func() (entities.AI, error)
+
+// LoadBot opens go plugins (*.so) right away, every other file is started as a new process bot
+// each time the factory is called
+func LoadBot(path string) (BotFactory, error) {
+	if filepath.Ext(path) == ".so" {
+		return LoadPlugin(path)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed loading bot: %w", err)
+	}
+	return func() (entities.AI, error) { return StartProcess(path) }, nil
+}
+
+// CloseBots stops bots that run in their own process
+func CloseBots(bots []entities.AI) {
+	for _, bot := range bots {
+		if closer, ok := bot.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Error().Err(err).Str("bot", bot.Name()).Msg("could not stop bot")
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package arena
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+type panicBot struct {
+	inInit bool
+}
+
+func (b *panicBot) Init(*rand.Rand, entities.ArenaInfo) {
+	if b.inInit {
+		panic("init failed")
+	}
+}
+func (b *panicBot) Name() string { return "panic" }
+func (b *panicBot) Compute(entities.AIInput) entities.AIOutput {
+	panic("compute failed")
+}
+
+func TestPanicIsolation(t *testing.T) {
+	var tests = []struct {
+		name      string
+		bot       *panicBot
+		budget    time.Duration
+		crashTick int64
+	}{
+		{"panic in init", &panicBot{inInit: true}, 0, 0},
+		{"panic in compute", &panicBot{}, 0, 0},
+		{"panic in compute with budget", &panicBot{}, time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld(testMap()).
+				WithComputeBudget(tt.budget, 0).
+				WithBots([]entities.AI{tt.bot, &constantBot{}, &constantBot{}})
+			w.Step()
+
+			p := w.Players[0]
+			if p.State != entities.Crashed {
+				t.Errorf("got state %s, want %s", p.State, entities.Crashed)
+			}
+			if p.CrashTick != tt.crashTick {
+				t.Errorf("got crash tick %d, want %d", p.CrashTick, tt.crashTick)
+			}
+			for _, other := range w.Players[1:] {
+				if other.State != entities.Alive {
+					t.Errorf("%s should still be alive", other.Name)
+				}
+			}
+			if w.GameOver {
+				t.Errorf("the remaining bots should keep playing")
+			}
+		})
+	}
+}
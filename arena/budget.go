@@ -0,0 +1,116 @@
+package arena
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+	"github.com/rs/zerolog/log"
+)
+
+type computeResult struct {
+	output entities.AIOutput
+	err    error
+}
+
+// compute asks the AI for its next move. Without a compute budget this is a plain call, otherwise
+// a bot that doesn't answer in time gets a no-op output. As a running Compute can't be aborted,
+// the bot isn't asked again until the late call returned.
+// A bot that panics is marked as crashed.
+func (w *World) compute(p *entities.Player, input entities.AIInput) entities.AIOutput {
+	if w.computeBudget <= 0 {
+		output, err := safeCompute(p.AI, input)
+		if err != nil {
+			return w.crash(p, err)
+		}
+		return output
+	}
+
+	if result, busy := w.pending[p.ID]; busy {
+		select {
+		case late := <-result:
+			// the late answer belongs to an older tick
+			delete(w.pending, p.ID)
+			if late.err != nil {
+				return w.crash(p, late.err)
+			}
+		default:
+			return w.overrun(p)
+		}
+	}
+
+	result := make(chan computeResult, 1)
+	go func() {
+		output, err := safeCompute(p.AI, input)
+		result <- computeResult{output, err}
+	}()
+
+	timer := time.NewTimer(w.computeBudget)
+	defer timer.Stop()
+	select {
+	case r := <-result:
+		if r.err != nil {
+			return w.crash(p, r.err)
+		}
+		return r.output
+	case <-timer.C:
+		w.pending[p.ID] = result
+		return w.overrun(p)
+	}
+}
+
+// crash takes a misbehaving bot out of the match
+func (w *World) crash(p *entities.Player, err error) entities.AIOutput {
+	p.State = entities.Crashed
+	p.CrashTick = w.Tick
+	p.Velocity = vector.Vec2{}
+
+	event := log.Error().Err(err).Int64("tick", w.Tick).Str("name", p.Name)
+	var botPanic *BotPanic
+	if errors.As(err, &botPanic) {
+		event = event.Str("stack", string(botPanic.Stack))
+	}
+	event.Msg("bot crashed")
+
+	return entities.AIOutput{}
+}
+
+func (w *World) overrun(p *entities.Player) entities.AIOutput {
+	p.Overruns++
+	log.Warn().Int64("tick", w.Tick).Str("name", p.Name).Int("overruns", p.Overruns).Dur("budget", w.computeBudget).Msg("bot exceeded compute budget")
+
+	if w.maxOverruns > 0 && p.Overruns >= w.maxOverruns {
+		p.State = entities.Disqualified
+		p.Velocity = vector.Vec2{}
+		log.Warn().Int64("tick", w.Tick).Str("name", p.Name).Int("overruns", p.Overruns).Msg("bot disqualified")
+
+		// bots in their own process can be stopped, plugins have to be left running
+		if closer, ok := p.AI.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Error().Err(err).Str("name", p.Name).Msg("could not stop bot")
+			}
+		}
+	}
+	return entities.AIOutput{}
+}
+
+// Close waits up to grace for the Compute calls that ran over the budget, a bot must not be used
+// again before its call returned. It returns the IDs of the players whose call is still running,
+// those bots can't be stopped and have to be given up.
+func (w *World) Close(grace time.Duration) []int {
+	deadline := time.Now().Add(grace)
+	var stuck []int
+	for id, result := range w.pending {
+		select {
+		case <-result:
+		case <-time.After(time.Until(deadline)):
+			stuck = append(stuck, id)
+		}
+		delete(w.pending, id)
+	}
+	sort.Ints(stuck)
+	return stuck
+}
@@ -0,0 +1,116 @@
+package arena
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+// slowBot blocks in Compute until it's released
+type slowBot struct {
+	release chan struct{}
+	calls   int
+	mu      sync.Mutex
+}
+
+func (b *slowBot) Init(*rand.Rand, entities.ArenaInfo) {}
+func (b *slowBot) Name() string                        { return "slow" }
+func (b *slowBot) Compute(entities.AIInput) entities.AIOutput {
+	b.mu.Lock()
+	b.calls++
+	b.mu.Unlock()
+	<-b.release
+	return entities.AIOutput{Speed: DefaultRules().MaxSpeed, Shoot: true}
+}
+
+func TestComputeBudget(t *testing.T) {
+	slow := &slowBot{release: make(chan struct{})}
+	w := NewWorld(testMap()).
+		WithComputeBudget(5*time.Millisecond, 3).
+		WithBots([]entities.AI{slow, &constantBot{}, &constantBot{}})
+	p := w.Players[0]
+
+	w.Step()
+	if p.Overruns != 1 || !reflect.DeepEqual(p.Output, entities.AIOutput{}) {
+		t.Errorf("got %d overruns and output %+v, want 1 and a no-op", p.Overruns, p.Output)
+	}
+
+	// the bot is still busy with the first tick and must not be called again
+	w.Step()
+	if p.Overruns != 2 {
+		t.Errorf("got %d overruns, want 2", p.Overruns)
+	}
+	slow.mu.Lock()
+	calls := slow.calls
+	slow.mu.Unlock()
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+
+	w.Step()
+	if p.State != entities.Disqualified {
+		t.Errorf("got state %s, want %s", p.State, entities.Disqualified)
+	}
+	close(slow.release)
+}
+
+func TestCloseWaitsForLateCompute(t *testing.T) {
+	slow := &slowBot{release: make(chan struct{})}
+	w := NewWorld(testMap()).
+		WithComputeBudget(time.Millisecond, 0).
+		WithBots([]entities.AI{slow, &constantBot{}})
+	w.Step()
+
+	closed := make(chan struct{})
+	go func() {
+		w.Close(time.Minute)
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatalf("close returned while compute was still running")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(slow.release)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatalf("close didn't return after compute finished")
+	}
+	if len(w.pending) != 0 {
+		t.Errorf("got %d pending calls after close, want none", len(w.pending))
+	}
+}
+
+func TestCloseGivesUpOnStuckCompute(t *testing.T) {
+	slow := &slowBot{release: make(chan struct{})}
+	defer close(slow.release)
+	w := NewWorld(testMap()).
+		WithComputeBudget(time.Millisecond, 0).
+		WithBots([]entities.AI{&constantBot{}, slow})
+	w.Step()
+
+	stuck := w.Close(10 * time.Millisecond)
+	if len(stuck) != 1 || stuck[0] != 1 {
+		t.Errorf("got stuck players %v, want [1]", stuck)
+	}
+	if len(w.pending) != 0 {
+		t.Errorf("got %d pending calls after close, want none", len(w.pending))
+	}
+}
+
+func TestComputeWithinBudget(t *testing.T) {
+	w := NewWorld(testMap()).
+		WithComputeBudget(time.Second, 1).
+		WithBots([]entities.AI{&constantBot{entities.AIOutput{Speed: 5}}, &constantBot{}})
+
+	w.Step()
+	if p := w.Players[0]; p.Overruns != 0 || p.Output.Speed != 5 {
+		t.Errorf("got %d overruns and output %+v", p.Overruns, p.Output)
+	}
+}
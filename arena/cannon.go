@@ -0,0 +1,49 @@
+package arena
+
+import (
+	"math"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// firePower clamps the requested power to the rules, bots not setting it shoot with power 1
+func (w *World) firePower(requested float64) float64 {
+	if requested == 0 {
+		requested = 1
+	}
+	return math.Max(w.Rules.MinFirePower, math.Min(w.Rules.MaxFirePower, requested))
+}
+
+// cannonReady tells if p could shoot in this tick with the lowest fire power, an empty tank can't fire
+func (w *World) cannonReady(p *entities.Player) bool {
+	return p.CannonCooldown <= 0 && p.Energy >= w.Rules.ShotEnergy*w.Rules.MinFirePower
+}
+
+// shoot fires a shell along the turret. Stronger shells deal more damage but are slower and
+// cost more energy and cooldown.
+func (w *World) shoot(p *entities.Player, power float64) {
+	energy := w.Rules.ShotEnergy * power
+	if p.CannonCooldown > 0 || p.Energy < energy {
+		return
+	}
+	p.CannonCooldown = int(math.Round(float64(w.Rules.CannonCooldown) * power))
+	p.AddEnergy(-energy)
+
+	newShell := entities.NewShell()
+	newShell.Source = p
+	newShell.Power = power
+	newShell.Movement = vector.Vec2{X: 1, Y: 0}.Rotate(p.TurretOrientation()).WithLength(w.shellSpeed(power))
+	newShell.Orientation = p.TurretOrientation()
+	newShell.Position = p.Position
+	newShell.Damage = int(math.Round(float64(w.Rules.ShellDamage) * power))
+	newShell.CollisionRadius = w.Rules.ShellRadius * math.Sqrt(power)
+
+	w.Shells = append(w.Shells, newShell)
+}
+
+// shellSpeed is ShellSpeed at power 1 and drops linearly to ShellSpeed/MaxFirePower at the
+// highest power, so shells never stop or fly backwards
+func (w *World) shellSpeed(power float64) float64 {
+	return w.Rules.ShellSpeed * (w.Rules.MaxFirePower + 1 - power) / w.Rules.MaxFirePower
+}
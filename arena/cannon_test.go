@@ -0,0 +1,97 @@
+package arena
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+func TestFirePower(t *testing.T) {
+	var tests = []struct {
+		name      string
+		requested float64
+		power     float64
+		damage    int
+		speed     float64
+		cooldown  int
+	}{
+		{"default", 0, 1, 15, 30, 60},
+		{"weak", 0.5, 0.5, 8, 35, 30},
+		{"strong", 3, 3, 45, 10, 180},
+		{"too strong", 10, 3, 45, 10, 180},
+		{"too weak", 0.01, 0.5, 8, 35, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld(testMap())
+			p := &entities.Player{Energy: 100, MaxEnergy: 100}
+
+			power := w.firePower(tt.requested)
+			if power != tt.power {
+				t.Fatalf("got power %f, want %f", power, tt.power)
+			}
+			w.shoot(p, power)
+			if len(w.Shells) != 1 {
+				t.Fatalf("got %d shells, want 1", len(w.Shells))
+			}
+
+			shell := w.Shells[0]
+			if shell.Damage != tt.damage {
+				t.Errorf("got damage %d, want %d", shell.Damage, tt.damage)
+			}
+			if math.Abs(shell.Movement.Length()-tt.speed) > 0.001 {
+				t.Errorf("got speed %f, want %f", shell.Movement.Length(), tt.speed)
+			}
+			if p.CannonCooldown != tt.cooldown {
+				t.Errorf("got cooldown %d, want %d", p.CannonCooldown, tt.cooldown)
+			}
+			if want := 100 - w.Rules.ShotEnergy*tt.power; math.Abs(p.Energy-want) > 0.001 {
+				t.Errorf("got energy %f, want %f", p.Energy, want)
+			}
+		})
+	}
+}
+
+func TestShootWithoutEnergy(t *testing.T) {
+	w := NewWorld(testMap())
+	p := &entities.Player{Energy: w.Rules.ShotEnergy, MaxEnergy: 100}
+
+	w.shoot(p, 2)
+	if len(w.Shells) != 0 {
+		t.Errorf("got %d shells, a shot must not cost more energy than left", len(w.Shells))
+	}
+}
+
+func TestShellSpeedFollowsPowerRange(t *testing.T) {
+	w := NewWorld(testMap())
+	w.Rules.MaxFirePower = 5
+
+	for _, tt := range []struct{ power, speed float64 }{{1, 30}, {4, 12}, {5, 6}} {
+		if speed := w.shellSpeed(tt.power); math.Abs(speed-tt.speed) > 0.001 {
+			t.Errorf("got speed %f at power %g, want %f", speed, tt.power, tt.speed)
+		}
+	}
+}
+
+func TestShellHitboxGrowsWithPower(t *testing.T) {
+	for _, tt := range []struct {
+		power float64
+		hit   bool
+	}{{1, false}, {3, true}} {
+		w := NewWorld(testMap()).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+		shooter, target := w.Players[0], w.Players[1]
+		w.shoot(shooter, tt.power)
+
+		// just out of reach of a power 1 shell
+		shell := w.Shells[0]
+		shell.Movement = vector.Vec2{}
+		shell.Position = vector.Vec2{X: target.Position.X + target.CollisionRadius + w.Rules.ShellRadius + 10, Y: target.Position.Y}
+		w.updatePlayer(target)
+		if target.Hit != tt.hit {
+			t.Errorf("got hit %t at power %g, want %t", target.Hit, tt.power, tt.hit)
+		}
+	}
+}
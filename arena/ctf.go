@@ -0,0 +1,176 @@
+package arena
+
+import (
+	"fmt"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/physics"
+	"github.com/gentoomaniac/go-arena/vector"
+	"github.com/rs/zerolog/log"
+)
+
+const defaultCaptures = 3
+
+type flag struct {
+	team     string
+	base     vector.Vec2
+	position vector.Vec2
+	carrier  *entities.Player
+}
+
+func (f *flag) atBase() bool {
+	return f.carrier == nil && f.position == f.base
+}
+
+// NewCaptureTheFlag needs a map with the flags of at least two teams, 0 captures uses the default
+func NewCaptureTheFlag(m *Map, captures int) (*CaptureTheFlag, error) {
+	if len(m.Flags) < 2 {
+		return nil, fmt.Errorf("capture the flag needs at least 2 flags, the map has %d", len(m.Flags))
+	}
+	if captures <= 0 {
+		captures = defaultCaptures
+	}
+
+	c := &CaptureTheFlag{Captures: captures, Scores: make(map[string]int)}
+	for _, f := range m.Flags {
+		c.flags = append(c.flags, &flag{team: f.Team, base: f.Position, position: f.Position})
+	}
+	return c, nil
+}
+
+// CaptureTheFlag is won by the first team bringing the enemy flag to its own base often enough.
+// Tanks pick up enemy flags by driving over them and drop them when they die, driving over the
+// own dropped flag returns it. A team can only score while its own flag is at home.
+type CaptureTheFlag struct {
+	noHooks
+	Captures int
+	Scores   map[string]int
+	flags    []*flag
+}
+
+func (c *CaptureTheFlag) OnTick(w *World) {
+	for _, f := range c.flags {
+		if f.carrier != nil {
+			c.carry(w, f)
+			continue
+		}
+
+		for _, p := range w.Players {
+			if p.State != entities.Alive || p.Team == "" || physics.Distance(p.Position, f.position) > p.CollisionRadius {
+				continue
+			}
+			if p.Team == f.team {
+				if !f.atBase() {
+					f.position = f.base
+					log.Info().Int64("tick", w.Tick).Str("name", p.Name).Str("flag", f.team).Msg("flag returned")
+				}
+				continue
+			}
+			if c.carrying(p) {
+				continue
+			}
+			f.carrier = p
+			log.Info().Int64("tick", w.Tick).Str("name", p.Name).Str("flag", f.team).Msg("flag picked up")
+			break
+		}
+	}
+}
+
+// carry moves the flag with its carrier and scores once the carrier made it home
+func (c *CaptureTheFlag) carry(w *World, f *flag) {
+	if f.carrier.State != entities.Alive {
+		log.Info().Int64("tick", w.Tick).Str("name", f.carrier.Name).Str("flag", f.team).Msg("flag dropped")
+		f.carrier = nil
+		return
+	}
+
+	f.position = f.carrier.Position
+	home := c.flagOf(f.carrier.Team)
+	if home == nil || !home.atBase() || physics.Distance(f.carrier.Position, home.base) > f.carrier.CollisionRadius {
+		return
+	}
+
+	c.Scores[f.carrier.Team]++
+	log.Info().Int64("tick", w.Tick).Str("name", f.carrier.Name).Str("team", f.carrier.Team).Int("score", c.Scores[f.carrier.Team]).Msg("flag captured")
+	f.carrier = nil
+	f.position = f.base
+}
+
+// CheckTeams makes sure every bot, given by its team, is in a team that has a flag on the map
+func (c *CaptureTheFlag) CheckTeams(teams []string) error {
+	for i, team := range teams {
+		if team == "" {
+			return fmt.Errorf("bot %d has no team, capture the flag is played in teams", i+1)
+		}
+		if c.flagOf(team) == nil {
+			return fmt.Errorf("team '%s' has no flag on the map", team)
+		}
+	}
+	return nil
+}
+
+func (c *CaptureTheFlag) flagOf(team string) *flag {
+	for _, f := range c.flags {
+		if f.team == team {
+			return f
+		}
+	}
+	return nil
+}
+
+func (c *CaptureTheFlag) carrying(p *entities.Player) bool {
+	for _, f := range c.flags {
+		if f.carrier == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Flags returns the current state of all flags
+func (c *CaptureTheFlag) Flags() []entities.Flag {
+	flags := make([]entities.Flag, 0, len(c.flags))
+	for _, f := range c.flags {
+		carrier := -1
+		if f.carrier != nil {
+			carrier = f.carrier.ID
+		}
+		flags = append(flags, entities.Flag{
+			Team:     f.team,
+			Position: f.position,
+			Base:     f.base,
+			AtBase:   f.atBase(),
+			Carrier:  carrier,
+		})
+	}
+	return flags
+}
+
+func (c *CaptureTheFlag) Observe(w *World, p *entities.Player, input *entities.AIInput) {
+	input.Flags = c.Flags()
+}
+
+func (c *CaptureTheFlag) IsOver(w *World) bool {
+	return c.capturedEnough() != "" || len(w.aliveSides()) <= 1
+}
+
+func (c *CaptureTheFlag) Winners(w *World) []*entities.Player {
+	if team := c.capturedEnough(); team != "" {
+		return w.playersOf(team)
+	}
+	return (&LastManStanding{}).Winners(w)
+}
+
+// capturedEnough returns the team that reached the captures needed to win
+func (c *CaptureTheFlag) capturedEnough() string {
+	for team, score := range c.Scores {
+		if score >= c.Captures {
+			return team
+		}
+	}
+	return ""
+}
+
+func (c *CaptureTheFlag) Scoreboard(w *World) []Score {
+	return scoreboard(w, func(s string) int { return c.Scores[s] })
+}
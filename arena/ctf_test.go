@@ -0,0 +1,106 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+func ctfWorld(t *testing.T) (*World, *CaptureTheFlag) {
+	m := testMap()
+	m.Flags = []Flag{{Team: "red", Position: vector.Vec2{X: 1000, Y: 1000}}, {Team: "blue", Position: vector.Vec2{X: 5000, Y: 5000}}}
+	ctf, err := NewCaptureTheFlag(m, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWorld(m).WithMode(ctf).WithTeams([]string{"red", "blue"}).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+	return w, ctf
+}
+
+func TestCaptureTheFlag(t *testing.T) {
+	w, ctf := ctfWorld(t)
+	red := w.Players[0]
+
+	// pick up the blue flag
+	red.Position = vector.Vec2{X: 5000, Y: 5000}
+	ctf.OnTick(w)
+	if flags := ctf.Flags(); flags[1].Carrier != red.ID {
+		t.Fatalf("got carrier %d, want %d", flags[1].Carrier, red.ID)
+	}
+
+	// carry it home
+	red.Position = vector.Vec2{X: 3000, Y: 3000}
+	ctf.OnTick(w)
+	if flags := ctf.Flags(); flags[1].Position != red.Position {
+		t.Errorf("flag at %s, want it with the carrier at %s", flags[1].Position, red.Position)
+	}
+	red.Position = vector.Vec2{X: 1000, Y: 1000}
+	ctf.OnTick(w)
+
+	if ctf.Scores["red"] != 1 {
+		t.Errorf("got score %d, want 1", ctf.Scores["red"])
+	}
+	if flags := ctf.Flags(); !flags[1].AtBase {
+		t.Errorf("captured flag is at %s, want it back at base", flags[1].Position)
+	}
+	w.isGameOver()
+	if team := w.WinningTeam(); team != "red" {
+		t.Errorf("got winner '%s', want red", team)
+	}
+}
+
+func TestFlagDrop(t *testing.T) {
+	w, ctf := ctfWorld(t)
+	red, blue := w.Players[0], w.Players[1]
+
+	red.Position = vector.Vec2{X: 5000, Y: 5000}
+	ctf.OnTick(w)
+	red.Position = vector.Vec2{X: 3000, Y: 3000}
+	ctf.OnTick(w)
+	red.State = entities.Dead
+	ctf.OnTick(w)
+
+	flags := ctf.Flags()
+	if flags[1].Carrier != -1 || flags[1].Position != (vector.Vec2{X: 3000, Y: 3000}) {
+		t.Fatalf("got %+v, want the flag dropped where the carrier died", flags[1])
+	}
+
+	// blue returns its flag
+	blue.Position = vector.Vec2{X: 3000, Y: 3000}
+	ctf.OnTick(w)
+	if flags := ctf.Flags(); !flags[1].AtBase {
+		t.Errorf("got %+v, want the flag returned", flags[1])
+	}
+}
+
+func TestCaptureTheFlagNeedsFlags(t *testing.T) {
+	if _, err := NewCaptureTheFlag(testMap(), 0); err == nil {
+		t.Errorf("got no error for a map without flags")
+	}
+}
+
+func TestCaptureTheFlagTeams(t *testing.T) {
+	var tests = []struct {
+		name    string
+		teams   []string
+		wantErr bool
+	}{
+		{"flag teams", []string{"red", "blue", "red"}, false},
+		{"unknown team", []string{"team1", "team2"}, true},
+		{"lone tank", []string{"red", "blue", ""}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ctf := ctfWorld(t)
+			err := ctf.CheckTeams(tt.teams)
+			if tt.wantErr && err == nil {
+				t.Errorf("got no error, want one")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("got error: %s", err)
+			}
+		})
+	}
+}
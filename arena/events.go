@@ -0,0 +1,32 @@
+package arena
+
+import (
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+// event queues e for the next AIInput of p
+func (w *World) event(p *entities.Player, e entities.Event) {
+	if p.State == entities.Disqualified || p.State == entities.Crashed {
+		return
+	}
+	e.Tick = w.Tick
+	p.Events = append(p.Events, e)
+}
+
+// die kills p and tells everyone about it, killer is nil if p didn't get shot
+func (w *World) die(p *entities.Player, killer *entities.Player) {
+	p.RespawnCooldown = w.Rules.RespawnWaitTime
+	p.State = entities.Dead
+	p.Deaths++
+
+	if killer != nil {
+		killer.Kills++
+		w.event(killer, entities.Event{Type: entities.Killed, Tank: p.ID})
+	}
+	for _, e := range w.Players {
+		if e != p {
+			w.event(e, entities.Event{Type: entities.EnemyDied, Tank: p.ID})
+		}
+	}
+	w.Mode.OnKill(w, p, killer)
+}
@@ -0,0 +1,93 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// recordingBot keeps all events it got
+type recordingBot struct {
+	constantBot
+	events []entities.Event
+}
+
+func (b *recordingBot) Compute(input entities.AIInput) entities.AIOutput {
+	b.events = append(b.events, input.Events...)
+	return b.output
+}
+
+func hasEvent(events []entities.Event, want entities.Event) bool {
+	for _, e := range events {
+		e.Tick = 0
+		if e == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestShellEvents(t *testing.T) {
+	bots := []*recordingBot{{}, {}, {}}
+	w := NewWorld(testMap()).WithRespawns(1).WithBots([]entities.AI{bots[0], bots[1], bots[2]})
+	shooter, target, bystander := w.Players[0], w.Players[1], w.Players[2]
+	target.Health = 10
+
+	shell := entities.NewShell()
+	shell.Source = shooter
+	shell.Power = 1
+	shell.Damage = 15
+	shell.Position = target.Position
+	w.Shells = append(w.Shells, shell)
+	for i := 0; i < w.Rules.RespawnWaitTime+5; i++ {
+		w.Step()
+	}
+
+	var tests = []struct {
+		name string
+		bot  *recordingBot
+		want entities.Event
+	}{
+		{"hit by shell", bots[target.ID], entities.Event{Type: entities.HitByShell, Tank: shooter.ID, Damage: 15}},
+		{"respawned", bots[target.ID], entities.Event{Type: entities.Respawned, Tank: -1}},
+		{"shell hit", bots[shooter.ID], entities.Event{Type: entities.ShellHit, Tank: target.ID, Damage: 15}},
+		{"killed", bots[shooter.ID], entities.Event{Type: entities.Killed, Tank: target.ID}},
+		{"enemy died", bots[bystander.ID], entities.Event{Type: entities.EnemyDied, Tank: target.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !hasEvent(tt.bot.events, tt.want) {
+				t.Errorf("got events %+v, want %+v", tt.bot.events, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventsAreDelivered(t *testing.T) {
+	w := NewWorld(testMap()).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+	p := w.Players[0]
+	w.event(p, entities.Event{Type: entities.Respawned, Tank: -1})
+
+	w.Step()
+	if len(p.Events) != 0 {
+		t.Errorf("got %d pending events after Compute, want 0", len(p.Events))
+	}
+}
+
+func TestShellMissed(t *testing.T) {
+	w := NewWorld(testMap()).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+	p := w.Players[0]
+
+	shell := entities.NewShell()
+	shell.Source = p
+	shell.Position = vector.Vec2{X: 10, Y: 10}
+	shell.Movement = vector.Vec2{X: -30, Y: 0}
+	w.Shells = append(w.Shells, shell)
+	w.Step()
+
+	if !hasEvent(p.Events, entities.Event{Type: entities.ShellMissed, Tank: -1}) {
+		t.Errorf("got events %+v, want a missed shell", p.Events)
+	}
+}
@@ -0,0 +1,102 @@
+package arena
+
+import (
+	"fmt"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/physics"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultZoneScore = 1000
+	defaultZoneTicks = 60 * 60 * 5
+)
+
+// NewKingOfTheHill needs a map with at least one zone, 0 for the limits uses the defaults
+func NewKingOfTheHill(m *Map, scoreLimit int, tickLimit int64) (*KingOfTheHill, error) {
+	if len(m.Zones) == 0 {
+		return nil, fmt.Errorf("king of the hill needs a map with zones")
+	}
+	if scoreLimit <= 0 {
+		scoreLimit = defaultZoneScore
+	}
+	if tickLimit <= 0 {
+		tickLimit = defaultZoneTicks
+	}
+
+	return &KingOfTheHill{
+		ScoreLimit: scoreLimit,
+		TickLimit:  tickLimit,
+		Scores:     make(map[string]int),
+		zones:      m.Zones,
+		owners:     make([][]*entities.Player, len(m.Zones)),
+	}, nil
+}
+
+// KingOfTheHill gives a point per tick and zone to the team or lone tank that is alone in it.
+// The match ends when a side reaches the score limit or after the tick limit.
+type KingOfTheHill struct {
+	noHooks
+	ScoreLimit int
+	TickLimit  int64
+	Scores     map[string]int // by team or, for tanks without a team, by tank
+	zones      []Zone
+	owners     [][]*entities.Player
+}
+
+func (k *KingOfTheHill) OnTick(w *World) {
+	for i, zone := range k.zones {
+		var inside []*entities.Player
+		sides := make(map[string]bool)
+		for _, p := range w.Players {
+			if p.State == entities.Alive && physics.PointInRectangle(p.Position, zone.Box) {
+				inside = append(inside, p)
+				sides[Side(p)] = true
+			}
+		}
+
+		if len(sides) != 1 {
+			k.owners[i] = nil
+			continue
+		}
+		if len(k.owners[i]) == 0 || Side(k.owners[i][0]) != Side(inside[0]) {
+			log.Info().Int64("tick", w.Tick).Str("zone", zone.Name).Str("name", inside[0].Name).Str("team", inside[0].Team).Msg("zone taken")
+		}
+		k.owners[i] = inside
+		k.Scores[Side(inside[0])]++
+	}
+}
+
+// Zones returns the zones and who holds them
+func (k *KingOfTheHill) Zones() []entities.Zone {
+	zones := make([]entities.Zone, 0, len(k.zones))
+	for i, zone := range k.zones {
+		owners := make([]int, 0, len(k.owners[i]))
+		for _, p := range k.owners[i] {
+			owners = append(owners, p.ID)
+		}
+		zones = append(zones, entities.Zone{Name: zone.Name, Box: zone.Box, Owners: owners})
+	}
+	return zones
+}
+
+func (k *KingOfTheHill) Observe(w *World, p *entities.Player, input *entities.AIInput) {
+	input.Zones = k.Zones()
+}
+
+func (k *KingOfTheHill) IsOver(w *World) bool {
+	s, best := leader(k.Scores)
+	return (s != "" && best >= k.ScoreLimit) || w.Tick >= k.TickLimit || len(w.aliveSides()) <= 1
+}
+
+func (k *KingOfTheHill) Winners(w *World) []*entities.Player {
+	if s, best := leader(k.Scores); s != "" && (best >= k.ScoreLimit || w.Tick >= k.TickLimit) {
+		return w.playersOf(s)
+	}
+	return (&LastManStanding{}).Winners(w)
+}
+
+func (k *KingOfTheHill) Scoreboard(w *World) []Score {
+	return scoreboard(w, func(s string) int { return k.Scores[s] })
+}
@@ -0,0 +1,89 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+func TestKingOfTheHill(t *testing.T) {
+	var tests = []struct {
+		name      string
+		teams     []string
+		positions []vector.Vec2
+		scores    map[string]int
+		owners    int
+	}{
+		{"empty zone", []string{"", ""}, []vector.Vec2{{X: 500, Y: 500}, {X: 5000, Y: 5000}}, map[string]int{}, 0},
+		{"lone tank", []string{"", ""}, []vector.Vec2{{X: 3200, Y: 3200}, {X: 5000, Y: 5000}}, map[string]int{"#0": 1}, 1},
+		{"contested", []string{"", ""}, []vector.Vec2{{X: 3000, Y: 3000}, {X: 3400, Y: 3400}}, map[string]int{}, 0},
+		{"team", []string{"red", "red"}, []vector.Vec2{{X: 3000, Y: 3000}, {X: 3400, Y: 3400}}, map[string]int{"red": 1}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testMap()
+			m.Zones = []Zone{{Name: "hill", Box: vector.Rect(2700, 2700, 3700, 3700)}}
+			koth, err := NewKingOfTheHill(m, 0, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := NewWorld(m).WithMode(koth).WithTeams(tt.teams).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+			for i, position := range tt.positions {
+				w.Players[i].Position = position
+			}
+
+			koth.OnTick(w)
+			if len(koth.Scores) != len(tt.scores) {
+				t.Errorf("got scores %v, want %v", koth.Scores, tt.scores)
+			}
+			for s, score := range tt.scores {
+				if koth.Scores[s] != score {
+					t.Errorf("got scores %v, want %v", koth.Scores, tt.scores)
+				}
+			}
+			if owners := koth.Zones()[0].Owners; len(owners) != tt.owners {
+				t.Errorf("got owners %v, want %d", owners, tt.owners)
+			}
+		})
+	}
+}
+
+func TestKingOfTheHillLimits(t *testing.T) {
+	m := testMap()
+	m.Zones = []Zone{{Name: "hill", Box: vector.Rect(2700, 2700, 3700, 3700)}}
+	koth, err := NewKingOfTheHill(m, 10, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWorld(m).WithMode(koth).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+	w.Players[0].Position = vector.Vec2{X: 3200, Y: 3200}
+
+	for i := 0; i < 10; i++ {
+		if w.GameOver {
+			t.Fatalf("game over after %d ticks", i)
+		}
+		w.Step()
+	}
+	if !w.GameOver {
+		t.Fatalf("game not over at the score limit")
+	}
+	if winner := w.Winner(); winner != w.Players[0] {
+		t.Errorf("got winner %v, want the king of the hill", winner)
+	}
+
+	koth.Scores = map[string]int{"#0": 3, "#1": 5}
+	w.GameOver = false
+	w.Tick = 100
+	w.isGameOver()
+	if winner := w.Winner(); !w.GameOver || winner != w.Players[1] {
+		t.Errorf("got winner %v at the tick limit, want the player with the most points", winner)
+	}
+}
+
+func TestKingOfTheHillNeedsZones(t *testing.T) {
+	if _, err := NewKingOfTheHill(testMap(), 0, 0); err == nil {
+		t.Errorf("got no error for a map without zones")
+	}
+}
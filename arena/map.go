@@ -0,0 +1,87 @@
+package arena
+
+import (
+	"fmt"
+
+	"github.com/gentoomaniac/go-arena/physics"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// Obstacle is a solid rectangle tanks and shells can't pass
+type Obstacle struct {
+	Name string
+	Box  vector.Rectangle
+}
+
+// Flag is the base of a team's flag for capture the flag
+type Flag struct {
+	Team     string
+	Position vector.Vec2
+}
+
+// Zone is a control zone for king of the hill
+type Zone struct {
+	Name string
+	Box  vector.Rectangle
+}
+
+// Map holds the parts of a level the simulation cares about
+type Map struct {
+	Width       int
+	Height      int
+	Obstacles   []Obstacle
+	SpawnPoints []vector.Vec2
+	Flags       []Flag
+	Zones       []Zone
+	// SafeZoneCenter is where the safe zone shrinks to, nil picks a random point
+	SafeZoneCenter *vector.Vec2
+}
+
+// Validate checks that the map can host a match with the given number of players
+func (m *Map) Validate(players int) error {
+	if m.Width <= 0 || m.Height <= 0 {
+		return fmt.Errorf("map has no size")
+	}
+	if len(m.SpawnPoints) < players {
+		return fmt.Errorf("map has %d spawn points but %d players", len(m.SpawnPoints), players)
+	}
+
+	bounds := vector.Rect(0, 0, float64(m.Width), float64(m.Height))
+	for _, spawnPoint := range m.SpawnPoints {
+		if !physics.PointInRectangle(spawnPoint, bounds) {
+			return fmt.Errorf("spawn point %s is outside of the map", spawnPoint)
+		}
+		for _, object := range m.Obstacles {
+			if physics.PointInRectangle(spawnPoint, object.Box) {
+				return fmt.Errorf("spawn point %s is inside of '%s'", spawnPoint, object.Name)
+			}
+		}
+	}
+	for _, flag := range m.Flags {
+		if !physics.PointInRectangle(flag.Position, bounds) {
+			return fmt.Errorf("flag of team '%s' is outside of the map", flag.Team)
+		}
+	}
+	if m.SafeZoneCenter != nil && !physics.PointInRectangle(*m.SafeZoneCenter, bounds) {
+		return fmt.Errorf("safe zone center %s is outside of the map", *m.SafeZoneCenter)
+	}
+	return nil
+}
+
+// LineOfSight reports whether the segment between a and b doesn't cross any obstacle
+func (m *Map) LineOfSight(a, b vector.Vec2) bool {
+	for _, object := range m.Obstacles {
+		corners := []vector.Vec2{
+			object.Box.Min,
+			{X: object.Box.Max.X, Y: object.Box.Min.Y},
+			object.Box.Max,
+			{X: object.Box.Min.X, Y: object.Box.Max.Y},
+		}
+		for i := range corners {
+			if physics.Intersection(a, b, corners[i], corners[(i+1)%len(corners)]) != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+func TestMapValidate(t *testing.T) {
+	var tests = []struct {
+		name    string
+		m       *Map
+		players int
+		wantErr bool
+	}{
+		{
+			"valid",
+			testMap(),
+			4,
+			false,
+		},
+		{
+			"too few spawn points",
+			testMap(),
+			5,
+			true,
+		},
+		{
+			"no spawn points",
+			&Map{Width: 100, Height: 100},
+			1,
+			true,
+		},
+		{
+			"spawn point outside",
+			&Map{Width: 100, Height: 100, SpawnPoints: []vector.Vec2{{X: 150, Y: 50}}},
+			1,
+			true,
+		},
+		{
+			"spawn point in obstacle",
+			&Map{
+				Width:       100,
+				Height:      100,
+				SpawnPoints: []vector.Vec2{{X: 50, Y: 50}},
+				Obstacles:   []Obstacle{{Name: "block", Box: vector.Rect(40, 40, 60, 60)}},
+			},
+			1,
+			true,
+		},
+		{
+			"safe zone center outside",
+			&Map{Width: 100, Height: 100, SpawnPoints: []vector.Vec2{{X: 50, Y: 50}}, SafeZoneCenter: &vector.Vec2{X: 50, Y: 150}},
+			1,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate(tt.players)
+			if tt.wantErr && err == nil {
+				t.Errorf("got no error, want one")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("got error: %s", err)
+			}
+		})
+	}
+}
+
+func TestLineOfSight(t *testing.T) {
+	m := &Map{
+		Width:     1000,
+		Height:    1000,
+		Obstacles: []Obstacle{{Name: "wall", Box: vector.Rect(400, 400, 600, 600)}},
+	}
+
+	var tests = []struct {
+		name string
+		a    vector.Vec2
+		b    vector.Vec2
+		want bool
+	}{
+		{"free", vector.Vec2{X: 100, Y: 100}, vector.Vec2{X: 900, Y: 100}, true},
+		{"through wall", vector.Vec2{X: 100, Y: 500}, vector.Vec2{X: 900, Y: 500}, false},
+		{"diagonal through wall", vector.Vec2{X: 100, Y: 100}, vector.Vec2{X: 900, Y: 900}, false},
+		{"ends before wall", vector.Vec2{X: 100, Y: 500}, vector.Vec2{X: 300, Y: 500}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.LineOfSight(tt.a, tt.b); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
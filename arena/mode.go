@@ -0,0 +1,222 @@
+package arena
+
+import (
+	"sort"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+const (
+	defaultKillLimit  = 10
+	defaultTimedTicks = 60 * 60 * 3
+)
+
+// GameMode decides how a match is scored and when it's over
+type GameMode interface {
+	// OnTick is called at the end of every tick
+	OnTick(w *World)
+	// OnKill is called when a tank died, killer is nil if it didn't get shot
+	OnKill(w *World, victim, killer *entities.Player)
+	// OnRespawn is called when a dead tank is back in the arena
+	OnRespawn(w *World, p *entities.Player)
+	// Observe adds what the mode wants a bot to know to its input
+	Observe(w *World, p *entities.Player, input *entities.AIInput)
+	IsOver(w *World) bool
+	// Winners returns the players of the winning team or the winning lone tank, nil if nobody won
+	Winners(w *World) []*entities.Player
+	// Scoreboard returns the points of every team and lone tank, best first
+	Scoreboard(w *World) []Score
+}
+
+// Score is the line of a team or lone tank on the scoreboard
+type Score struct {
+	Name    string // the team or the name of the lone tank
+	Points  int
+	Players []int
+}
+
+// noHooks can be embedded by modes that don't care about some of the events
+type noHooks struct{}
+
+func (noHooks) OnTick(*World)                                       {}
+func (noHooks) OnKill(*World, *entities.Player, *entities.Player)   {}
+func (noHooks) OnRespawn(*World, *entities.Player)                  {}
+func (noHooks) Observe(*World, *entities.Player, *entities.AIInput) {}
+
+// scoreboard groups the players by side, points returns the score of a side
+func scoreboard(w *World, points func(side string) int) []Score {
+	var scores []Score
+	index := make(map[string]int)
+	for _, p := range w.Players {
+		s := Side(p)
+		i, ok := index[s]
+		if !ok {
+			name := p.Team
+			if name == "" {
+				name = p.Name
+			}
+			i = len(scores)
+			index[s] = i
+			scores = append(scores, Score{Name: name, Points: points(s)})
+		}
+		scores[i].Players = append(scores[i].Players, p.ID)
+	}
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].Points > scores[j].Points })
+	return scores
+}
+
+// leader returns the side with the most points and its score, "" on a tie
+func leader(scores map[string]int) (string, int) {
+	leader, best, tie := "", 0, false
+	for s, score := range scores {
+		switch {
+		case leader == "" && !tie, score > best:
+			leader, best, tie = s, score, false
+		case score == best:
+			tie = true
+		}
+	}
+	if tie {
+		return "", best
+	}
+	return leader, best
+}
+
+// killsBySide sums up the kills of every team and lone tank
+func (w *World) killsBySide() map[string]int {
+	kills := make(map[string]int)
+	for _, p := range w.Players {
+		kills[Side(p)] += p.Kills
+	}
+	return kills
+}
+
+// LastManStanding ends the match when only one team or lone tank is left
+type LastManStanding struct {
+	noHooks
+}
+
+func (m *LastManStanding) IsOver(w *World) bool {
+	return len(w.aliveSides()) <= 1
+}
+
+func (m *LastManStanding) Winners(w *World) []*entities.Player {
+	alive := w.alivePlayers()
+	if len(alive) == 0 || len(w.aliveSides()) != 1 {
+		return nil
+	}
+	return w.playersOf(Side(alive[0]))
+}
+
+func (m *LastManStanding) Scoreboard(w *World) []Score {
+	kills := w.killsBySide()
+	return scoreboard(w, func(s string) int { return kills[s] })
+}
+
+// killScores gives a point for every enemy killed and takes one for every teammate killed
+type killScores map[string]int
+
+func (k killScores) OnKill(w *World, victim, killer *entities.Player) {
+	switch {
+	case killer == nil:
+	case teammates(victim, killer):
+		k[Side(killer)]--
+	default:
+		k[Side(killer)]++
+	}
+}
+
+// NewDeathmatch creates a deathmatch, 0 for the kill limit uses the default
+func NewDeathmatch(killLimit int) *Deathmatch {
+	if killLimit <= 0 {
+		killLimit = defaultKillLimit
+	}
+	return &Deathmatch{KillLimit: killLimit, Scores: make(killScores)}
+}
+
+// Deathmatch is won by the first team or lone tank reaching the kill limit. It's meant to be
+// played with respawns, when they run out the last side standing wins.
+type Deathmatch struct {
+	noHooks
+	KillLimit int
+	Scores    killScores
+}
+
+func (d *Deathmatch) OnKill(w *World, victim, killer *entities.Player) {
+	d.Scores.OnKill(w, victim, killer)
+}
+
+func (d *Deathmatch) IsOver(w *World) bool {
+	_, best := leader(d.Scores)
+	return best >= d.KillLimit || len(w.aliveSides()) <= 1
+}
+
+func (d *Deathmatch) Winners(w *World) []*entities.Player {
+	if s, best := leader(d.Scores); s != "" && best >= d.KillLimit {
+		return w.playersOf(s)
+	}
+	return (&LastManStanding{}).Winners(w)
+}
+
+func (d *Deathmatch) Scoreboard(w *World) []Score {
+	return scoreboard(w, func(s string) int { return d.Scores[s] })
+}
+
+// NewTimedScore creates a timed match, 0 for the tick limit uses the default
+func NewTimedScore(tickLimit int64) *TimedScore {
+	if tickLimit <= 0 {
+		tickLimit = defaultTimedTicks
+	}
+	return &TimedScore{TickLimit: tickLimit, Scores: make(killScores)}
+}
+
+// TimedScore runs for a fixed number of ticks, the side with the most points wins. Kills score
+// like in a deathmatch and crashing into a wall or object costs a point.
+type TimedScore struct {
+	noHooks
+	TickLimit int64
+	Scores    killScores
+}
+
+func (t *TimedScore) OnKill(w *World, victim, killer *entities.Player) {
+	if killer == nil {
+		t.Scores[Side(victim)]--
+		return
+	}
+	t.Scores.OnKill(w, victim, killer)
+}
+
+func (t *TimedScore) IsOver(w *World) bool {
+	return w.Tick >= t.TickLimit || len(w.aliveSides()) <= 1
+}
+
+func (t *TimedScore) Winners(w *World) []*entities.Player {
+	if w.Tick < t.TickLimit {
+		return (&LastManStanding{}).Winners(w)
+	}
+	// sides without any points are missing from the scores but still beat negative ones
+	points := make(map[string]int)
+	for _, p := range w.Players {
+		points[Side(p)] = t.Scores[Side(p)]
+	}
+	if s, _ := leader(points); s != "" {
+		return w.playersOf(s)
+	}
+	return nil
+}
+
+func (t *TimedScore) Scoreboard(w *World) []Score {
+	return scoreboard(w, func(s string) int { return t.Scores[s] })
+}
+
+// ScoreOf returns the points of the player's team or, without a team, of the player
+func (w *World) ScoreOf(p *entities.Player) int {
+	for _, score := range w.Mode.Scoreboard(w) {
+		for _, id := range score.Players {
+			if id == p.ID {
+				return score.Points
+			}
+		}
+	}
+	return 0
+}
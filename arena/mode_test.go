@@ -0,0 +1,118 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+// hookMode counts the calls of the event hooks
+type hookMode struct {
+	LastManStanding
+	ticks, kills, respawns int
+}
+
+func (m *hookMode) OnTick(*World)                                     { m.ticks++ }
+func (m *hookMode) OnKill(*World, *entities.Player, *entities.Player) { m.kills++ }
+func (m *hookMode) OnRespawn(*World, *entities.Player)                { m.respawns++ }
+
+func TestModeHooks(t *testing.T) {
+	mode := &hookMode{}
+	w := NewWorld(testMap()).WithMode(mode).WithRespawns(1).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+
+	w.die(w.Players[0], w.Players[1])
+	for i := 0; i < int(w.Rules.RespawnWaitTime)+2; i++ {
+		w.Step()
+	}
+	if mode.ticks != int(w.Tick) || mode.kills != 1 || mode.respawns != 1 {
+		t.Errorf("got %d ticks, %d kills, %d respawns, want %d, 1, 1", mode.ticks, mode.kills, mode.respawns, w.Tick)
+	}
+}
+
+func TestDeathmatch(t *testing.T) {
+	var tests = []struct {
+		name   string
+		teams  []string
+		kills  [][2]int // victim, killer
+		scores map[string]int
+		over   bool
+	}{
+		{"kill", nil, [][2]int{{1, 0}}, map[string]int{"#0": 1}, false},
+		{"kill limit", nil, [][2]int{{1, 0}, {1, 0}}, map[string]int{"#0": 2}, true},
+		{"teamkill", []string{"red", "red", "blue"}, [][2]int{{1, 0}}, map[string]int{"red": -1}, false},
+		{"crash", nil, [][2]int{{1, -1}}, map[string]int{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dm := NewDeathmatch(2)
+			w := NewWorld(testMap()).WithMode(dm).WithRespawns(5).WithTeams(tt.teams).
+				WithBots([]entities.AI{&constantBot{}, &constantBot{}, &constantBot{}})
+			for _, kill := range tt.kills {
+				var killer *entities.Player
+				if kill[1] >= 0 {
+					killer = w.Players[kill[1]]
+				}
+				w.die(w.Players[kill[0]], killer)
+			}
+
+			for s, score := range tt.scores {
+				if dm.Scores[s] != score {
+					t.Errorf("got scores %v, want %v", dm.Scores, tt.scores)
+				}
+			}
+			if over := dm.IsOver(w); over != tt.over {
+				t.Errorf("got over %t, want %t", over, tt.over)
+			}
+			if tt.over {
+				w.GameOver = true
+				if winner := w.Winner(); winner != w.Players[0] {
+					t.Errorf("got winner %v, want the player at the kill limit", winner)
+				}
+			}
+		})
+	}
+}
+
+func TestTimedScore(t *testing.T) {
+	timed := NewTimedScore(100)
+	w := NewWorld(testMap()).WithMode(timed).WithRespawns(5).
+		WithBots([]entities.AI{&constantBot{}, &constantBot{}, &constantBot{}})
+
+	w.die(w.Players[1], w.Players[0])
+	w.die(w.Players[0], nil)
+	w.die(w.Players[2], nil)
+	if timed.IsOver(w) {
+		t.Fatalf("game over before the tick limit")
+	}
+
+	// #0 and #1 both have 0 points, the crash put #2 behind
+	w.Tick = 100
+	w.isGameOver()
+	if winners := timed.Winners(w); !w.GameOver || winners != nil {
+		t.Errorf("got winners %v on a tie, want nil", winners)
+	}
+
+	w.die(w.Players[2], w.Players[1])
+	if winner := w.Winner(); winner != w.Players[1] {
+		t.Errorf("got winner %v, want the player with the most points", winner)
+	}
+}
+
+func TestScoreboard(t *testing.T) {
+	dm := NewDeathmatch(0)
+	w := NewWorld(testMap()).WithMode(dm).WithRespawns(5).WithTeams([]string{"red", "blue", "red"}).
+		WithBots([]entities.AI{&constantBot{}, &constantBot{}, &constantBot{}})
+	w.die(w.Players[0], w.Players[1])
+
+	scores := dm.Scoreboard(w)
+	if len(scores) != 2 || scores[0].Name != "blue" || scores[0].Points != 1 || len(scores[1].Players) != 2 {
+		t.Errorf("got scoreboard %+v, want blue ahead of both red players", scores)
+	}
+	if score := w.ScoreOf(w.Players[2]); score != 0 {
+		t.Errorf("got score %d for red, want 0", score)
+	}
+	if score := w.ScoreOf(w.Players[1]); score != 1 {
+		t.Errorf("got score %d for blue, want 1", score)
+	}
+}
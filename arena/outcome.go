@@ -0,0 +1,106 @@
+package arena
+
+import (
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/rs/zerolog/log"
+)
+
+// Reason tells why a match ended
+type Reason string
+
+const (
+	LastStanding Reason = "last standing" // only one team or lone tank was left
+	Points       Reason = "points"        // the game mode's objective decided the match
+	Timeout      Reason = "timeout"       // the tick limit ran out and health and kills decided
+	Draw         Reason = "draw"          // nobody won
+)
+
+// WithTickLimit ends the match after maxTicks, 0 means no limit. With suddenDeathTicks the match
+// goes into sudden death instead: health is halved, nobody respawns anymore and the match ends
+// after suddenDeathTicks more ticks.
+func (w *World) WithTickLimit(maxTicks, suddenDeathTicks int64) *World {
+	w.maxTicks = maxTicks
+	w.suddenDeathTicks = suddenDeathTicks
+	return w
+}
+
+func (w *World) isGameOver() {
+	if w.GameOver {
+		return
+	}
+
+	switch {
+	case w.Mode.IsOver(w):
+		w.end(false)
+	case w.maxTicks > 0 && w.Tick >= w.maxTicks+w.suddenDeathTicks:
+		w.end(true)
+	case w.maxTicks > 0 && w.Tick >= w.maxTicks && !w.SuddenDeath:
+		w.startSuddenDeath()
+	}
+}
+
+func (w *World) startSuddenDeath() {
+	w.SuddenDeath = true
+	for _, p := range w.Players {
+		p.MaxRespawns = p.NumberRespawns
+		if p.State == entities.Alive && p.Health > 1 {
+			p.Health /= 2
+		}
+	}
+	log.Info().Int64("tick", w.Tick).Int64("ticks", w.suddenDeathTicks).Msg("sudden death")
+}
+
+func (w *World) end(timedOut bool) {
+	w.GameOver = true
+	w.timedOut = timedOut
+
+	winners := w.Winners()
+	switch {
+	case len(winners) == 0:
+		w.Reason = Draw
+	case timedOut:
+		w.Reason = Timeout
+	case len(w.aliveSides()) == 1 && w.aliveSides()[Side(winners[0])]:
+		w.Reason = LastStanding
+	default:
+		w.Reason = Points
+	}
+}
+
+// Winners returns the players of the winning team or the winning lone tank, nil while the match
+// is running or if it's a draw
+func (w *World) Winners() []*entities.Player {
+	if !w.GameOver {
+		return nil
+	}
+	if w.timedOut {
+		return w.timeoutWinners()
+	}
+	return w.Mode.Winners(w)
+}
+
+// timeoutWinners picks the side with the most health left, kills break ties
+func (w *World) timeoutWinners() []*entities.Player {
+	health, kills := make(map[string]int), w.killsBySide()
+	for _, p := range w.Players {
+		if p.State == entities.Alive {
+			health[Side(p)] += p.Health
+		}
+	}
+
+	best, tie := "", false
+	for _, p := range w.Players {
+		s := Side(p)
+		switch {
+		case s == best:
+		case best == "", health[s] > health[best], health[s] == health[best] && kills[s] > kills[best]:
+			best, tie = s, false
+		case health[s] == health[best] && kills[s] == kills[best]:
+			tie = true
+		}
+	}
+	if tie || best == "" {
+		return nil
+	}
+	return w.playersOf(best)
+}
@@ -0,0 +1,101 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+func TestTickLimit(t *testing.T) {
+	var tests = []struct {
+		name   string
+		health []int
+		kills  []int
+		winner int
+		reason Reason
+	}{
+		{"most health", []int{80, 50}, []int{0, 0}, 0, Timeout},
+		{"kills break ties", []int{50, 50}, []int{0, 2}, 1, Timeout},
+		{"draw", []int{50, 50}, []int{1, 1}, -1, Draw},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld(testMap()).WithTickLimit(10, 0).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+			for i, p := range w.Players {
+				p.Health = tt.health[i]
+				p.Kills = tt.kills[i]
+			}
+
+			for i := 0; i < 10; i++ {
+				if w.GameOver {
+					t.Fatalf("game over after %d ticks", i)
+				}
+				w.Step()
+			}
+			if !w.GameOver || w.Reason != tt.reason {
+				t.Fatalf("got game over %t with reason '%s', want '%s'", w.GameOver, w.Reason, tt.reason)
+			}
+			if winner := w.Winner(); (tt.winner < 0 && winner != nil) || (tt.winner >= 0 && winner != w.Players[tt.winner]) {
+				t.Errorf("got winner %v, want player %d", winner, tt.winner)
+			}
+		})
+	}
+}
+
+func TestSuddenDeath(t *testing.T) {
+	w := NewWorld(testMap()).WithTickLimit(10, 20).WithRespawns(3).
+		WithBots([]entities.AI{&constantBot{}, &constantBot{}, &constantBot{}})
+	w.die(w.Players[2], nil)
+
+	for i := 0; i < 10; i++ {
+		w.Step()
+	}
+	if !w.SuddenDeath || w.GameOver {
+		t.Fatalf("got sudden death %t and game over %t, want only sudden death", w.SuddenDeath, w.GameOver)
+	}
+	if health := w.Players[0].Health; health != w.Rules.Health/2 {
+		t.Errorf("got health %d, want %d", health, w.Rules.Health/2)
+	}
+
+	// the dead player doesn't come back, the match ends when the sudden death is over
+	w.Players[0].Health--
+	w.Players[2].RespawnCooldown = 0
+	for i := 0; i < int(w.Rules.RespawnWaitTime); i++ {
+		w.Step()
+		if w.Players[2].State == entities.Alive {
+			t.Fatalf("player respawned in sudden death")
+		}
+		if w.GameOver {
+			break
+		}
+	}
+	if w.Tick != 30 || w.Reason != Timeout || w.Winner() != w.Players[1] {
+		t.Errorf("got end in tick %d with reason '%s' and winner %v, want tick 30 and player 1 on timeout", w.Tick, w.Reason, w.Winner())
+	}
+}
+
+func TestReason(t *testing.T) {
+	w := NewWorld(testMap()).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+	w.die(w.Players[1], w.Players[0])
+	w.isGameOver()
+	if w.Reason != LastStanding || w.Winner() != w.Players[0] {
+		t.Errorf("got reason '%s' and winner %v, want the last tank standing", w.Reason, w.Winner())
+	}
+
+	w = NewWorld(testMap()).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+	w.die(w.Players[0], nil)
+	w.die(w.Players[1], nil)
+	w.isGameOver()
+	if w.Reason != Draw || w.Winner() != nil {
+		t.Errorf("got reason '%s' and winner %v, want a draw", w.Reason, w.Winner())
+	}
+
+	dm := NewDeathmatch(1)
+	w = NewWorld(testMap()).WithMode(dm).WithRespawns(1).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+	w.die(w.Players[1], w.Players[0])
+	w.isGameOver()
+	if w.Reason != Points || w.Winner() != w.Players[0] {
+		t.Errorf("got reason '%s' and winner %v, want a win on points", w.Reason, w.Winner())
+	}
+}
@@ -0,0 +1,241 @@
+package arena
+
+import (
+	"math"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/physics"
+	"github.com/gentoomaniac/go-arena/vector"
+	"github.com/rs/zerolog/log"
+)
+
+func (w *World) updatePlayer(p *entities.Player) {
+	enemies := w.enemies(p)
+
+	if p.State == entities.Alive {
+		input := entities.AIInput{
+			Position:          p.Position,
+			TargetSpeed:       p.TargetSpeed,
+			MaxSpeed:          p.MaxSpeed,
+			CurrentSpeed:      p.Velocity.Length(),
+			Orientation:       p.Velocity.Angle(),
+			Turret:            p.Turret,
+			TurretOrientation: p.TurretOrientation(),
+			Collided:          p.Collided,
+			CollidedWithTank:  p.CollidedWithTank,
+			Hit:               p.Hit,
+			CannonReady:       w.cannonReady(p),
+			Energy:            p.Energy,
+			MaxEnergy:         p.MaxEnergy,
+			Enemy:             enemies,
+			Projectiles:       w.projectiles(p),
+			Events:            p.Events,
+			ReceivedMessages:  w.received(p),
+		}
+		w.Mode.Observe(w, p, &input)
+		output := w.compute(p, input)
+		p.Output = output
+		p.Events = nil
+		w.send(p, output.Messages)
+
+		p.UpdateSpeed(output.Speed)
+
+		if output.OrientationChange > 0 {
+			if math.Abs(output.OrientationChange) <= w.Rules.MaxTurnPerTick {
+				p.UpdateOrientation(output.OrientationChange)
+			} else {
+				p.UpdateOrientation(w.Rules.MaxTurnPerTick * (output.OrientationChange / math.Abs(output.OrientationChange)))
+			}
+		}
+
+		turretChange := math.Max(-w.Rules.MaxTurretTurnPerTick, math.Min(w.Rules.MaxTurretTurnPerTick, output.TurretChange))
+		p.Turret = normalizeAngle(p.Turret + turretChange)
+
+		p.AddEnergy(w.Rules.EnergyRegen)
+		if p.CannonCooldown > 0 {
+			p.CannonCooldown--
+		} else if output.Shoot {
+			w.shoot(p, w.firePower(output.FirePower))
+		}
+	} else if p.State == entities.Dead {
+		p.Output = entities.AIOutput{}
+		p.UpdateSpeed(0)
+		if p.NumberRespawns < p.MaxRespawns {
+			if p.RespawnCooldown > 0 {
+				p.RespawnCooldown--
+			} else {
+				p.TargetSpeed = 0
+				p.Velocity = vector.Vec2{}
+				p.Turret = 0
+				p.Orientation = vector.Vec2{X: w.rng.Float64(), Y: w.rng.Float64()}
+				spawnPoint := w.Map.SpawnPoints[w.rng.Int()%len(w.Map.SpawnPoints)]
+				p.Position.X = spawnPoint.X
+				p.Position.Y = spawnPoint.Y
+				p.State = entities.Alive
+				p.Health = p.MaxHealth
+				p.Energy = p.MaxEnergy
+				p.NumberRespawns++
+				w.event(p, entities.Event{Type: entities.Respawned, Tank: -1})
+				w.Mode.OnRespawn(w, p)
+			}
+		}
+	}
+
+	// Collisions
+	for index, e := range w.Players {
+		if e != p {
+			circleDistance := physics.DistanceBetweenCircles(vector.Circle{e.Position, e.CollisionRadius}, vector.Circle{p.Position, p.CollisionRadius})
+
+			// ToDo: needs refactor to break this code into physics module and write tests for it
+			// collisions: https://www.youtube.com/watch?v=LPzyNOHY3A4&ab_channel=javidx9
+			if circleDistance < 0 {
+				// check for static collision
+				displaceBy := math.Abs(circleDistance) / 2
+				vPlayerEnemy := p.Position.ToPoint(e.Position)
+
+				// ToDo: Multiple collisions happen right after one another which causes hughe spikes in ImpactVelocity
+				// ToDo: This can move a tank out of the level boundaries
+				vDisplace := vPlayerEnemy.Unit().ScalarProduct(displaceBy)
+				p.Position.X += vDisplace.X
+				p.Position.Y += vDisplace.Y
+				w.Players[index].Position.X -= vDisplace.X
+				w.Players[index].Position.Y -= vDisplace.Y
+
+				p.CollidedWithTank = true
+				w.Players[index].CollidedWithTank = true
+				w.event(p, entities.Event{Type: entities.TankCollision, Tank: e.ID})
+				w.event(e, entities.Event{Type: entities.TankCollision, Tank: p.ID})
+
+				// vector between center points
+				vecPE := p.Position.ToPoint(e.Position)
+
+				// normal vector between balls
+				normal := vecPE.Unit()
+
+				// perpendicular vector
+				tangent := normal.Perpendicular()
+
+				// Dot Product Tangent
+				dpTanP := p.Velocity.DotProduct(tangent)
+				dpTanE := e.Velocity.DotProduct(tangent)
+
+				// Dot Product Normal
+				dpNormP := p.Velocity.DotProduct(normal)
+				dpNormE := e.Velocity.DotProduct(normal)
+
+				// Conservation of momentum in D
+				mP := (dpNormP*(p.Mass-e.Mass) + 2.0*e.Mass*dpNormE) / (p.Mass + e.Mass)
+				mE := (dpNormE*(e.Mass-p.Mass) + 2.0*p.Mass*dpNormP) / (p.Mass + e.Mass)
+
+				// Update impact velocity // Switched +/-
+				p.Velocity.X -= (tangent.X*dpTanP + normal.X*mP) * w.Rules.ImpactScaling
+				p.Velocity.Y -= (tangent.Y*dpTanP + normal.Y*mP) * w.Rules.ImpactScaling
+				w.Players[index].Velocity.X += (tangent.X*dpTanE + normal.X*mE) * w.Rules.ImpactScaling
+				w.Players[index].Velocity.Y += (tangent.Y*dpTanE + normal.Y*mE) * w.Rules.ImpactScaling
+
+			}
+		}
+	}
+
+	width := float64(w.Map.Width)
+	height := float64(w.Map.Height)
+	collisionPoint := vector.Vec2{X: p.Position.X + p.Velocity.X, Y: p.Position.Y + p.Velocity.Y}
+	p.Collided = false
+	// check left border
+	if collisionPoint.X-p.CollisionRadius < 0.0 || physics.PointLineDistance(vector.Vec2{0, 0}, vector.Vec2{0, height}, collisionPoint) <= p.CollisionRadius {
+		p.Collided = true
+		p.Health -= w.Rules.CollisionDamage
+		p.Position.X = p.CollisionRadius + 1
+		p.Velocity.X = 0
+		w.event(p, entities.Event{Type: entities.WallCollision, Tank: -1, Side: "left"})
+		log.Debug().Int64("tick", w.Tick).Str("name", p.Name).Str("new", p.Position.String()).Msg("collided left")
+	}
+	// check right border
+	if collisionPoint.X+p.CollisionRadius > width ||
+		physics.PointLineDistance(vector.Vec2{width, 0}, vector.Vec2{width, height}, collisionPoint) <= p.CollisionRadius {
+		p.Collided = true
+		p.Health -= w.Rules.CollisionDamage
+		p.Position.X = width - p.CollisionRadius - 1
+		p.Velocity.X = 0
+		w.event(p, entities.Event{Type: entities.WallCollision, Tank: -1, Side: "right"})
+		log.Debug().Int64("tick", w.Tick).Str("name", p.Name).Str("new", p.Position.String()).Msg("collided right")
+	}
+	// check top border
+	if collisionPoint.Y-p.CollisionRadius < 0.0 ||
+		physics.PointLineDistance(vector.Vec2{0, 0}, vector.Vec2{width, 0}, collisionPoint) <= p.CollisionRadius {
+		p.Collided = true
+		p.Health -= w.Rules.CollisionDamage
+		p.Position.Y = p.CollisionRadius + 1
+		p.Velocity.Y = 0
+		w.event(p, entities.Event{Type: entities.WallCollision, Tank: -1, Side: "top"})
+		log.Debug().Int64("tick", w.Tick).Str("name", p.Name).Str("new", p.Position.String()).Msg("collided top")
+	}
+	// check bottom border
+	if collisionPoint.Y+p.CollisionRadius > height ||
+		physics.PointLineDistance(vector.Vec2{0, height}, vector.Vec2{width, height}, collisionPoint) <= p.CollisionRadius {
+		p.Collided = true
+		p.Health -= w.Rules.CollisionDamage
+		p.Position.Y = height - p.CollisionRadius - 1
+		p.Velocity.Y = 0
+		w.event(p, entities.Event{Type: entities.WallCollision, Tank: -1, Side: "bottom"})
+		log.Debug().Int64("tick", w.Tick).Str("name", p.Name).Str("new", p.Position.String()).Msg("collided bottom")
+	}
+
+	if p.Collided {
+		if p.Health <= 0 && p.State == entities.Alive {
+			w.die(p, nil)
+			log.Info().Str("name", p.Name).Msg("crashed into level boundary")
+		}
+	}
+
+	// check map objects, the tank gets pushed out and keeps only the velocity along the object's side
+	for _, object := range w.Map.Obstacles {
+		displacement := physics.CircleRectangleDisplacement(vector.Circle{p.Position, p.CollisionRadius}, object.Box)
+		if displacement == nil {
+			continue
+		}
+
+		p.Collided = true
+		p.Health -= w.Rules.CollisionDamage
+		p.Position.X += displacement.X
+		p.Position.Y += displacement.Y
+
+		normal := displacement.Unit()
+		if intoObject := p.Velocity.DotProduct(normal); intoObject < 0 {
+			p.Velocity.X -= normal.X * intoObject
+			p.Velocity.Y -= normal.Y * intoObject
+		}
+		w.event(p, entities.Event{Type: entities.WallCollision, Tank: -1, Object: object.Name})
+		log.Debug().Int64("tick", w.Tick).Str("name", p.Name).Str("object", object.Name).Str("new", p.Position.String()).Msg("collided with object")
+
+		if p.Health <= 0 && p.State == entities.Alive {
+			w.die(p, nil)
+			log.Info().Str("name", p.Name).Str("object", object.Name).Int("max", p.MaxRespawns).Int("spawns", p.NumberRespawns).Msg("crashed into object")
+		}
+	}
+
+	// check hit by shell
+	p.Hit = false
+	for i, shell := range w.Shells {
+		if shell.Source != p && (w.Rules.FriendlyFire || !teammates(shell.Source, p)) {
+			if distance := physics.DistanceBetweenCircles(
+				vector.Circle{shell.Position, shell.CollisionRadius},
+				vector.Circle{p.Position, p.CollisionRadius}); distance < 0 {
+
+				// ToDo: This makes the shell disappear before it visually hit
+				// the shell should get a hit flag and get removed after the next draw
+				w.Shells = remove(w.Shells, i)
+				p.Hit = true
+				p.Health -= shell.Damage
+				shell.Source.AddEnergy(w.Rules.HitEnergyRefund * shell.Power)
+				w.event(p, entities.Event{Type: entities.HitByShell, Tank: shell.Source.ID, Damage: shell.Damage})
+				w.event(shell.Source, entities.Event{Type: entities.ShellHit, Tank: p.ID, Damage: shell.Damage})
+				//ToDo: shell impact causes velocity change
+				if p.Health <= 0 && p.State == entities.Alive {
+					w.die(p, shell.Source)
+					log.Info().Str("target", p.Name).Str("source", shell.Source.Name).Int("max", p.MaxRespawns).Int("spawns", p.NumberRespawns).Msg("killed")
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package arena
+
+import (
+	"fmt"
+	"plugin"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+// LoadPlugin opens a bot compiled with -buildmode=plugin, the factory calls its exported NewBot
+func LoadPlugin(path string) (BotFactory, error) {
+	botPlugin, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading bot: %w", err)
+	}
+	newBotObj, err := botPlugin.Lookup("NewBot")
+	if err != nil {
+		return nil, fmt.Errorf("no function called 'NewBot' found: %w", err)
+	}
+	newBot, ok := newBotObj.(func() entities.AI)
+	if !ok {
+		return nil, fmt.Errorf("NewBot has to be a func() entities.AI")
+	}
+	return func() (entities.AI, error) { return newBot(), nil }, nil
+}
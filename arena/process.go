@@ -0,0 +1,132 @@
+package arena
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+// InitMessage is the first line sent to a process bot
+type InitMessage struct {
+	Seed  int64              `json:"seed"`
+	Arena entities.ArenaInfo `json:"arena"`
+}
+
+// HelloMessage is the answer of a process bot to the InitMessage
+type HelloMessage struct {
+	Name string `json:"name"`
+}
+
+// StartProcess launches an executable that talks to the arena with JSON lines on stdin/stdout.
+// After receiving an InitMessage the bot has to answer with a HelloMessage, then for every tick
+// it gets an entities.AIInput and has to answer with an entities.AIOutput.
+func StartProcess(path string, args ...string) (*ProcessBot, error) {
+	cmd := exec.Command(path, args...)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed starting bot: %w", err)
+	}
+
+	return &ProcessBot{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewScanner(stdout),
+		name:   filepath.Base(path),
+	}, nil
+}
+
+// ProcessBot is an entities.AI running in its own process
+type ProcessBot struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+	name   string
+	err    error
+}
+
+func (b *ProcessBot) Init(rng *rand.Rand, arena entities.ArenaInfo) {
+	var hello HelloMessage
+	if err := b.exchange(InitMessage{Seed: rng.Int63(), Arena: arena}, &hello); err != nil {
+		return
+	}
+	if hello.Name != "" {
+		b.name = hello.Name
+	}
+}
+
+// Compute returns a no-op output if the bot doesn't answer properly, Err tells why
+func (b *ProcessBot) Compute(input entities.AIInput) entities.AIOutput {
+	var output entities.AIOutput
+	if err := b.exchange(input, &output); err != nil {
+		return entities.AIOutput{}
+	}
+	return output
+}
+
+func (b *ProcessBot) Name() string {
+	return b.name
+}
+
+// Err returns why the process broke the protocol, nil as long as it works
+func (b *ProcessBot) Err() error {
+	return b.err
+}
+
+// exchange sends a single line and decodes the answer. Once the process broke the protocol
+// it's not talked to anymore.
+func (b *ProcessBot) exchange(request interface{}, response interface{}) error {
+	if b.err != nil {
+		return b.err
+	}
+
+	line, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	if _, err := b.stdin.Write(append(line, '\n')); err != nil {
+		b.err = fmt.Errorf("bot process is not responding: %w", err)
+		return b.err
+	}
+
+	if !b.stdout.Scan() {
+		b.err = io.ErrUnexpectedEOF
+		if err := b.stdout.Err(); err != nil {
+			b.err = err
+		}
+		b.err = fmt.Errorf("bot process is not responding: %w", b.err)
+		return b.err
+	}
+	if err := json.Unmarshal(b.stdout.Bytes(), response); err != nil {
+		b.err = fmt.Errorf("invalid answer from bot: %w", err)
+		return b.err
+	}
+	return nil
+}
+
+// Close stops the bot process
+func (b *ProcessBot) Close() error {
+	b.stdin.Close()
+	if err := b.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	// the exit status of a killed bot is of no interest
+	b.cmd.Wait()
+	return nil
+}
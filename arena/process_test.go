@@ -0,0 +1,128 @@
+package arena
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+// TestHelperProcess isn't a real test, it's the bot process started by the other tests
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_ARENA_HELPER_PROCESS") != "1" {
+		return
+	}
+	defer os.Exit(0)
+
+	in := bufio.NewScanner(os.Stdin)
+	in.Scan()
+	fmt.Println(`{"name": "helper"}`)
+	for in.Scan() {
+		// like most bots the helper can't handle a second init message
+		var init InitMessage
+		if json.Unmarshal(in.Bytes(), &init); init.Seed != 0 {
+			os.Exit(1)
+		}
+		var input entities.AIInput
+		json.Unmarshal(in.Bytes(), &input)
+		if os.Getenv("GO_ARENA_HELPER_GARBAGE") == "1" {
+			fmt.Println("garbage")
+			continue
+		}
+		fmt.Printf(`{"speed": %f, "orientationChange": 1, "shoot": %t}`+"\n", input.MaxSpeed, input.CannonReady)
+	}
+}
+
+func startHelper(t *testing.T, env ...string) *ProcessBot {
+	os.Setenv("GO_ARENA_HELPER_PROCESS", "1")
+	defer os.Unsetenv("GO_ARENA_HELPER_PROCESS")
+	for i := 0; i < len(env); i += 2 {
+		os.Setenv(env[i], env[i+1])
+		defer os.Unsetenv(env[i])
+	}
+
+	bot, err := StartProcess(os.Args[0], "-test.run=TestHelperProcess")
+	if err != nil {
+		t.Fatalf("could not start helper: %s", err)
+	}
+	return bot
+}
+
+func TestProcessBot(t *testing.T) {
+	bot := startHelper(t)
+	defer bot.Close()
+
+	bot.Init(rand.New(rand.NewSource(1)), entities.ArenaInfo{})
+	if bot.Name() != "helper" {
+		t.Errorf("got name '%s', want 'helper'", bot.Name())
+	}
+
+	output := bot.Compute(entities.AIInput{MaxSpeed: 25, CannonReady: true})
+	want := entities.AIOutput{Speed: 25, OrientationChange: 1, Shoot: true}
+	if !reflect.DeepEqual(output, want) {
+		t.Errorf("got %+v, want %+v", output, want)
+	}
+}
+
+func TestProcessBotGarbage(t *testing.T) {
+	bot := startHelper(t, "GO_ARENA_HELPER_GARBAGE", "1")
+	defer bot.Close()
+
+	bot.Init(rand.New(rand.NewSource(1)), entities.ArenaInfo{})
+	if err := bot.Err(); err != nil {
+		t.Fatalf("got error after a valid hello: %s", err)
+	}
+	for i := 0; i < 2; i++ {
+		if output := bot.Compute(entities.AIInput{MaxSpeed: 25}); !reflect.DeepEqual(output, entities.AIOutput{}) {
+			t.Errorf("got %+v, want a no-op output", output)
+		}
+	}
+	if bot.Err() == nil {
+		t.Errorf("got no error after garbage")
+	}
+}
+
+func TestProcessBotGarbageCrashes(t *testing.T) {
+	bot := startHelper(t, "GO_ARENA_HELPER_GARBAGE", "1")
+	defer bot.Close()
+
+	w := NewWorld(testMap()).WithBots([]entities.AI{bot, &constantBot{}})
+	w.Step()
+	if p := w.Players[0]; p.State != entities.Crashed || p.Name != "helper" {
+		t.Errorf("got state %v for '%s', want a crashed helper", p.State, p.Name)
+	}
+}
+
+// every match needs a new process, the helper like most bots reads the init line only once
+func TestTournamentProcessBots(t *testing.T) {
+	var started []*ProcessBot
+	helper := func() (entities.AI, error) {
+		bot := startHelper(t)
+		started = append(started, bot)
+		return bot, nil
+	}
+
+	_, err := NewTournament(testMap()).
+		WithRounds(3).
+		WithFormat(Pairs).
+		WithMaxTicks(5).
+		AddBot("a", helper).
+		AddBot("b", helper).
+		Run()
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(started) != 6 {
+		t.Errorf("got %d processes, want one per bot and match", len(started))
+	}
+	for i, bot := range started {
+		if err := bot.Err(); err != nil {
+			t.Errorf("process %d broke: %s", i, err)
+		}
+	}
+}
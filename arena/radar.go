@@ -0,0 +1,95 @@
+package arena
+
+import (
+	"math"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/physics"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// canSee tells if the target is in view range, inside the field of view and not hidden behind an obstacle
+func (w *World) canSee(p *entities.Player, target vector.Vec2) bool {
+	if physics.Distance(p.Position, target) > w.Rules.ViewRange {
+		return false
+	}
+	if w.Rules.FieldOfView > 0 && math.Abs(relativeAngle(p, target)) > w.Rules.FieldOfView/2 {
+		return false
+	}
+	return w.Map.LineOfSight(p.Position, target)
+}
+
+// relativeAngle is the angle from p's orientation to the target
+func relativeAngle(p *entities.Player, target vector.Vec2) float64 {
+	return normalizeAngle((math.Atan2(target.Y-p.Position.Y, target.X-p.Position.X) * 180 / math.Pi) - p.Velocity.Angle())
+}
+
+// enemies returns everything p knows about the other tanks: the visible ones and the last
+// sighting of those that went out of sight
+func (w *World) enemies(p *entities.Player) []*entities.Enemy {
+	seen, ok := w.sightings[p.ID]
+	if !ok {
+		seen = make(map[int]entities.Enemy)
+		w.sightings[p.ID] = seen
+	}
+
+	enemies := make([]*entities.Enemy, 0)
+	for _, e := range w.Players {
+		if e == p {
+			continue
+		}
+
+		if w.canSee(p, e.Position) {
+			seen[e.ID] = entities.Enemy{
+				ID:       e.ID,
+				Name:     e.Name,
+				Team:     e.Team,
+				Position: e.Position,
+				Velocity: e.Velocity,
+				Heading:  e.Velocity.Angle(),
+				Health:   e.Health,
+				Speed:    e.Velocity.Length(),
+				State:    e.State,
+				LastSeen: w.Tick,
+			}
+		}
+
+		sighting, ok := seen[e.ID]
+		if !ok {
+			continue
+		}
+		sighting.Visible = sighting.LastSeen == w.Tick
+		sighting.Distance = physics.Distance(p.Position, sighting.Position)
+		sighting.Angle = relativeAngle(p, sighting.Position)
+		enemies = append(enemies, &sighting)
+	}
+	return enemies
+}
+
+// projectiles returns the shells of other tanks p can see
+func (w *World) projectiles(p *entities.Player) []*entities.Projectile {
+	projectiles := make([]*entities.Projectile, 0)
+	for _, s := range w.Shells {
+		if s.Source == p || !w.canSee(p, s.Position) {
+			continue
+		}
+		projectiles = append(projectiles, &entities.Projectile{
+			Position: vector.Vec2{X: s.Position.X - p.Position.X, Y: s.Position.Y - p.Position.Y},
+			Velocity: s.Movement,
+			Power:    s.Power,
+			Owner:    s.Source.ID,
+		})
+	}
+	return projectiles
+}
+
+// normalizeAngle maps an angle in degrees to (-180, 180]
+func normalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle > 180 {
+		angle -= 360
+	} else if angle <= -180 {
+		angle += 360
+	}
+	return angle
+}
@@ -0,0 +1,114 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+func TestVisibleEnemies(t *testing.T) {
+	var tests = []struct {
+		name        string
+		enemy       vector.Vec2
+		fieldOfView float64
+		obstacles   []Obstacle
+		want        int
+	}{
+		{"in range", vector.Vec2{X: 2000, Y: 1000}, 0, nil, 1},
+		{"out of range", vector.Vec2{X: 4000, Y: 1000}, 0, nil, 0},
+		{"behind wall", vector.Vec2{X: 2000, Y: 1000}, 0, []Obstacle{{Name: "wall", Box: vector.Rect(1400, 500, 1600, 1500)}}, 0},
+		{"inside field of view", vector.Vec2{X: 2000, Y: 1200}, 90, nil, 1},
+		{"behind the tank", vector.Vec2{X: 500, Y: 1000}, 90, nil, 0},
+		{"all around", vector.Vec2{X: 500, Y: 1000}, 0, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld(&Map{Width: 6400, Height: 6400, Obstacles: tt.obstacles})
+			w.Rules.FieldOfView = tt.fieldOfView
+			p := &entities.Player{Position: vector.Vec2{X: 1000, Y: 1000}, Velocity: vector.Vec2{X: 1, Y: 0}}
+			w.Players = []*entities.Player{p, {ID: 1, Position: tt.enemy}}
+
+			if got := w.enemies(p); len(got) != tt.want {
+				t.Errorf("got %d visible enemies, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestEnemyMemory(t *testing.T) {
+	m := &Map{Width: 6400, Height: 6400, Obstacles: []Obstacle{{Name: "wall", Box: vector.Rect(1400, 500, 1600, 1500)}}}
+	w := NewWorld(m)
+	p := &entities.Player{Position: vector.Vec2{X: 1000, Y: 1000}}
+	e := &entities.Player{ID: 1, Name: "enemy", Health: 80, Position: vector.Vec2{X: 1000, Y: 2000}, Velocity: vector.Vec2{X: 10, Y: 0}}
+	w.Players = []*entities.Player{p, e}
+
+	enemies := w.enemies(p)
+	if len(enemies) != 1 || !enemies[0].Visible {
+		t.Fatalf("got %+v, want one visible enemy", enemies)
+	}
+	if enemies[0].ID != 1 || enemies[0].Name != "enemy" || enemies[0].Health != 80 || enemies[0].Velocity != e.Velocity || enemies[0].LastSeen != 0 {
+		t.Errorf("got %+v, want the enemy's current state", enemies[0])
+	}
+
+	// hide behind the wall
+	w.Tick = 5
+	e.Position = vector.Vec2{X: 2000, Y: 1000}
+	enemies = w.enemies(p)
+	if len(enemies) != 1 {
+		t.Fatalf("got %d enemies, want the last sighting", len(enemies))
+	}
+	if enemies[0].Visible || enemies[0].LastSeen != 0 || enemies[0].Position != (vector.Vec2{X: 1000, Y: 2000}) {
+		t.Errorf("got %+v, want the sighting from tick 0", enemies[0])
+	}
+}
+
+func TestProjectiles(t *testing.T) {
+	m := &Map{Width: 6400, Height: 6400, Obstacles: []Obstacle{{Name: "wall", Box: vector.Rect(1400, 500, 1600, 1500)}}}
+	w := NewWorld(m)
+	p := &entities.Player{Position: vector.Vec2{X: 1000, Y: 1000}}
+	e := &entities.Player{ID: 1}
+	w.Players = []*entities.Player{p, e}
+
+	shell := func(source *entities.Player, x, y float64) *entities.Shell {
+		s := entities.NewShell()
+		s.Source = source
+		s.Position = vector.Vec2{X: x, Y: y}
+		s.Movement = vector.Vec2{X: -30, Y: 0}
+		return s
+	}
+	w.Shells = []*entities.Shell{
+		shell(e, 1000, 2000), // visible
+		shell(e, 2000, 1000), // behind the wall
+		shell(e, 5000, 5000), // out of range
+		shell(p, 1200, 1000), // own shell
+	}
+
+	projectiles := w.projectiles(p)
+	if len(projectiles) != 1 {
+		t.Fatalf("got %d projectiles, want 1", len(projectiles))
+	}
+	if got := projectiles[0]; got.Position != (vector.Vec2{X: 0, Y: 1000}) || got.Velocity != (vector.Vec2{X: -30, Y: 0}) || got.Owner != 1 {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestNormalizeAngle(t *testing.T) {
+	var tests = []struct {
+		angle float64
+		want  float64
+	}{
+		{0, 0},
+		{190, -170},
+		{-190, 170},
+		{540, 180},
+		{-45, -45},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeAngle(tt.angle); got != tt.want {
+			t.Errorf("normalizeAngle(%f): got %f, want %f", tt.angle, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package arena
+
+import (
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/rs/zerolog/log"
+)
+
+// teamMessage is a message on its way to the team of the sender
+type teamMessage struct {
+	team    string
+	message entities.Message
+}
+
+// send queues the messages of p for its teammates, everything beyond the per tick size limit is dropped
+func (w *World) send(p *entities.Player, payloads []string) {
+	if p.Team == "" {
+		return
+	}
+
+	size := 0
+	for i, payload := range payloads {
+		size += len(payload)
+		if size > w.Rules.MaxMessageBytes {
+			log.Debug().Int64("tick", w.Tick).Str("name", p.Name).Int("dropped", len(payloads)-i).Msg("radio limit exceeded")
+			return
+		}
+		w.outbox = append(w.outbox, teamMessage{
+			team:    p.Team,
+			message: entities.Message{From: p.ID, Tick: w.Tick, Payload: payload},
+		})
+	}
+}
+
+// received returns the messages p's teammates sent in the last tick
+func (w *World) received(p *entities.Player) []entities.Message {
+	messages := make([]entities.Message, 0)
+	for _, m := range w.inbox {
+		if m.team == p.Team && m.message.From != p.ID {
+			messages = append(messages, m.message)
+		}
+	}
+	return messages
+}
+
+// deliverMessages makes the messages sent in this tick available in the next one
+func (w *World) deliverMessages() {
+	w.inbox, w.outbox = w.outbox, nil
+}
@@ -0,0 +1,43 @@
+package arena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+// listeningBot keeps the radio messages it received per tick
+type listeningBot struct {
+	constantBot
+	received [][]entities.Message
+}
+
+func (b *listeningBot) Compute(input entities.AIInput) entities.AIOutput {
+	b.received = append(b.received, input.ReceivedMessages)
+	return b.output
+}
+
+func TestRadio(t *testing.T) {
+	long := strings.Repeat("x", DefaultRules().MaxMessageBytes)
+	sender := &constantBot{entities.AIOutput{Messages: []string{"focus #2", long}}}
+	teammate := &listeningBot{}
+	enemy := &listeningBot{}
+	w := NewWorld(testMap()).WithTeams([]string{"a", "a", "b"}).WithBots([]entities.AI{sender, teammate, enemy})
+
+	w.Step()
+	w.Step()
+
+	if len(teammate.received[0]) != 0 {
+		t.Errorf("got %v in the first tick, messages need a tick to arrive", teammate.received[0])
+	}
+	want := entities.Message{From: 0, Tick: 0, Payload: "focus #2"}
+	if len(teammate.received[1]) != 1 || teammate.received[1][0] != want {
+		t.Errorf("got %v, want only %v as the second message exceeds the size limit", teammate.received[1], want)
+	}
+	for _, messages := range enemy.received {
+		if len(messages) != 0 {
+			t.Errorf("enemy overheard %v", messages)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package arena
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"os"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// Replay is a recording of every tick of a match
+type Replay struct {
+	MapPath          string
+	Seed             int64
+	Mode             ReplayMode
+	MaxTicks         int64
+	SuddenDeathTicks int64
+	Players          []ReplayPlayer
+	Frames           []Frame
+}
+
+// ReplayPlayer holds the values of a player that don't change during a match
+type ReplayPlayer struct {
+	ID              int
+	Name            string
+	Team            string
+	MaxHealth       int
+	MaxEnergy       float64
+	MaxSpeed        float64
+	CollisionRadius float64
+	MaxRespawns     int
+}
+
+// Frame is the state of the world after a single tick
+type Frame struct {
+	Tick        int64
+	Players     []PlayerFrame
+	Shells      []ShellFrame
+	Mode        ModeFrame
+	SuddenDeath bool
+	GameOver    bool
+	Reason      Reason
+	TimedOut    bool
+}
+
+type PlayerFrame struct {
+	Position       vector.Vec2
+	Velocity       vector.Vec2
+	Orientation    vector.Vec2
+	Turret         float64
+	Health         int
+	Energy         float64
+	State          entities.State
+	NumberRespawns int
+	Kills          int
+	Deaths         int
+	CrashTick      int64
+	Output         entities.AIOutput
+}
+
+type ShellFrame struct {
+	Position    vector.Vec2
+	Movement    vector.Vec2
+	Orientation float64
+	Power       float64
+	Source      int
+}
+
+func NewRecorder(w *World, mapPath string) *Recorder {
+	r := &Recorder{
+		world: w,
+		replay: &Replay{
+			MapPath:          mapPath,
+			Seed:             w.Seed,
+			Mode:             recordMode(w),
+			MaxTicks:         w.maxTicks,
+			SuddenDeathTicks: w.suddenDeathTicks,
+		},
+	}
+	for _, p := range w.Players {
+		r.replay.Players = append(r.replay.Players, ReplayPlayer{
+			ID:              p.ID,
+			Name:            p.Name,
+			Team:            p.Team,
+			MaxHealth:       p.MaxHealth,
+			MaxEnergy:       p.MaxEnergy,
+			MaxSpeed:        p.MaxSpeed,
+			CollisionRadius: p.CollisionRadius,
+			MaxRespawns:     p.MaxRespawns,
+		})
+	}
+	r.capture()
+
+	return r
+}
+
+// Recorder collects the frames of a running match
+type Recorder struct {
+	world  *World
+	replay *Replay
+}
+
+// Capture records the current state of the world if it advanced since the last call
+func (r *Recorder) Capture() {
+	if r.replay.Frames[len(r.replay.Frames)-1].Tick == r.world.Tick {
+		return
+	}
+	r.capture()
+}
+
+func (r *Recorder) capture() {
+	frame := Frame{
+		Tick:        r.world.Tick,
+		Mode:        captureMode(r.world),
+		SuddenDeath: r.world.SuddenDeath,
+		GameOver:    r.world.GameOver,
+		Reason:      r.world.Reason,
+		TimedOut:    r.world.timedOut,
+	}
+	for _, p := range r.world.Players {
+		frame.Players = append(frame.Players, PlayerFrame{
+			Position:       p.Position,
+			Velocity:       p.Velocity,
+			Orientation:    p.Orientation,
+			Turret:         p.Turret,
+			Health:         p.Health,
+			Energy:         p.Energy,
+			State:          p.State,
+			NumberRespawns: p.NumberRespawns,
+			Kills:          p.Kills,
+			Deaths:         p.Deaths,
+			CrashTick:      p.CrashTick,
+			Output:         p.Output,
+		})
+	}
+	for _, s := range r.world.Shells {
+		frame.Shells = append(frame.Shells, ShellFrame{
+			Position:    s.Position,
+			Movement:    s.Movement,
+			Orientation: s.Orientation,
+			Power:       s.Power,
+			Source:      s.Source.ID,
+		})
+	}
+	r.replay.Frames = append(r.replay.Frames, frame)
+}
+
+func (r *Recorder) Replay() *Replay {
+	return r.replay
+}
+
+// Save writes the replay as gzip compressed gob
+func (r *Replay) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	if err := gob.NewEncoder(zw).Encode(r); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func LoadReplay(path string) (*Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	r := &Replay{}
+	if err := gob.NewDecoder(zr).Decode(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func NewPlayback(r *Replay, m *Map) *Playback {
+	w := NewWorld(m).
+		WithMode(r.Mode.gameMode(r.Frames[0].Mode)).
+		WithTickLimit(r.MaxTicks, r.SuddenDeathTicks)
+	w.Seed = r.Seed
+	for _, rp := range r.Players {
+		w.Players = append(w.Players, &entities.Player{
+			ID:              rp.ID,
+			Name:            rp.Name,
+			Team:            rp.Team,
+			MaxHealth:       rp.MaxHealth,
+			MaxEnergy:       rp.MaxEnergy,
+			MaxSpeed:        rp.MaxSpeed,
+			CollisionRadius: rp.CollisionRadius,
+			MaxRespawns:     rp.MaxRespawns,
+		})
+	}
+
+	pb := &Playback{World: w, replay: r}
+	pb.apply(r.Frames[0])
+	return pb
+}
+
+// Playback moves a world through the frames of a replay instead of simulating it
+type Playback struct {
+	World  *World
+	replay *Replay
+	frame  int
+}
+
+func (pb *Playback) Step() {
+	if pb.frame >= len(pb.replay.Frames)-1 {
+		pb.World.GameOver = true
+		return
+	}
+	pb.frame++
+	pb.apply(pb.replay.Frames[pb.frame])
+}
+
+func (pb *Playback) apply(f Frame) {
+	pb.World.Tick = f.Tick
+	for i, pf := range f.Players {
+		p := pb.World.Players[i]
+		p.Position = pf.Position
+		p.Velocity = pf.Velocity
+		p.Orientation = pf.Orientation
+		p.Turret = pf.Turret
+		p.Health = pf.Health
+		p.Energy = pf.Energy
+		p.State = pf.State
+		p.NumberRespawns = pf.NumberRespawns
+		p.Kills = pf.Kills
+		p.Deaths = pf.Deaths
+		p.CrashTick = pf.CrashTick
+		p.Output = pf.Output
+	}
+
+	pb.World.Shells = pb.World.Shells[:0]
+	for _, sf := range f.Shells {
+		shell := entities.NewShell()
+		shell.Position = sf.Position
+		shell.Movement = sf.Movement
+		shell.Orientation = sf.Orientation
+		shell.Power = sf.Power
+		if shell.Power == 0 {
+			// recorded before shells had a fire power
+			shell.Power = 1
+		}
+		shell.Source = pb.World.Players[sf.Source]
+		pb.World.Shells = append(pb.World.Shells, shell)
+	}
+	pb.applyMode(f.Mode)
+
+	// the outcome is replayed as recorded, the playback doesn't decide the match itself
+	pb.World.SuddenDeath = f.SuddenDeath
+	if f.GameOver {
+		pb.World.GameOver = true
+		pb.World.Reason = f.Reason
+		pb.World.timedOut = f.TimedOut
+	}
+}
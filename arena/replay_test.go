@@ -0,0 +1,139 @@
+package arena
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+func TestReplay(t *testing.T) {
+	w := NewWorld(testMap()).WithSeed(7).WithBots([]entities.AI{&randomBot{}, &randomBot{}})
+	recorder := NewRecorder(w, "maps/test.tmx")
+	for i := 0; i < 200; i++ {
+		w.Step()
+		recorder.Capture()
+	}
+
+	path := filepath.Join(t.TempDir(), "test.replay")
+	if err := recorder.Replay().Save(path); err != nil {
+		t.Fatalf("could not save replay: %s", err)
+	}
+	r, err := LoadReplay(path)
+	if err != nil {
+		t.Fatalf("could not load replay: %s", err)
+	}
+	if len(r.Frames) != 201 {
+		t.Fatalf("got %d frames, want 201", len(r.Frames))
+	}
+
+	pb := NewPlayback(r, testMap())
+	for i := 0; i < 200; i++ {
+		pb.Step()
+	}
+	for i, p := range pb.World.Players {
+		if p.Position != w.Players[i].Position || p.Health != w.Players[i].Health {
+			t.Errorf("player %d differs from recording: got '%s' want '%s'", i, p.Position, w.Players[i].Position)
+		}
+	}
+	if len(pb.World.Shells) != len(w.Shells) {
+		t.Errorf("got %d shells, want %d", len(pb.World.Shells), len(w.Shells))
+	}
+
+	pb.Step()
+	if !pb.World.GameOver {
+		t.Errorf("playback should be over after the last frame")
+	}
+}
+
+// roundTrip records a few ticks of the world, saves and loads the replay and plays it back
+func roundTrip(t *testing.T, w *World, ticks int) *Playback {
+	recorder := NewRecorder(w, "maps/test.tmx")
+	for i := 0; i < ticks; i++ {
+		w.Step()
+		recorder.Capture()
+	}
+
+	path := filepath.Join(t.TempDir(), "test.replay")
+	if err := recorder.Replay().Save(path); err != nil {
+		t.Fatalf("could not save replay: %s", err)
+	}
+	r, err := LoadReplay(path)
+	if err != nil {
+		t.Fatalf("could not load replay: %s", err)
+	}
+
+	pb := NewPlayback(r, testMap())
+	for i := 0; i < ticks; i++ {
+		pb.Step()
+	}
+	return pb
+}
+
+func TestReplayCaptureTheFlag(t *testing.T) {
+	w, _ := ctfWorld(t)
+	w.Players[0].Position = vector.Vec2{X: 5000, Y: 5000}
+
+	pb := roundTrip(t, w, 2)
+	ctf, ok := pb.World.Mode.(*CaptureTheFlag)
+	if !ok {
+		t.Fatalf("got mode %T, want capture the flag", pb.World.Mode)
+	}
+	if flags := ctf.Flags(); len(flags) != 2 || flags[1].Carrier != 0 || flags[0].Base != w.Map.Flags[0].Position {
+		t.Errorf("got flags %+v, want the blue flag carried by red", flags)
+	}
+}
+
+func TestReplayKingOfTheHill(t *testing.T) {
+	m := testMap()
+	m.Zones = []Zone{{Name: "hill", Box: vector.Rect(2700, 2700, 3700, 3700)}}
+	koth, err := NewKingOfTheHill(m, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWorld(m).WithMode(koth).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+	w.Players[0].Position = vector.Vec2{X: 3200, Y: 3200}
+
+	// the playback map has no zones, they come from the replay
+	pb := roundTrip(t, w, 3)
+	played, ok := pb.World.Mode.(*KingOfTheHill)
+	if !ok {
+		t.Fatalf("got mode %T, want king of the hill", pb.World.Mode)
+	}
+	if played.Scores["#0"] != 3 || pb.World.ScoreOf(pb.World.Players[0]) != 3 {
+		t.Errorf("got scores %v, want 3 points for the first player", played.Scores)
+	}
+	if zones := played.Zones(); len(zones) != 1 || len(zones[0].Owners) != 1 || zones[0].Owners[0] != 0 {
+		t.Errorf("got zones %+v, want the hill held by the first player", zones)
+	}
+}
+
+func TestReplaySafeZone(t *testing.T) {
+	w := NewWorld(testMap()).WithSeed(3).WithMode(NewSafeZone(testMap(), 10)).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+	zone := w.Mode.(*SafeZone)
+	zone.Delay = 0
+
+	pb := roundTrip(t, w, 5)
+	played, ok := pb.World.Mode.(*SafeZone)
+	if !ok {
+		t.Fatalf("got mode %T, want the safe zone", pb.World.Mode)
+	}
+	if got, want := played.Zone(pb.World), zone.Zone(w); got != want {
+		t.Errorf("got zone %+v, want %+v", got, want)
+	}
+}
+
+func TestReplayTimeout(t *testing.T) {
+	w := NewWorld(testMap()).WithTickLimit(10, 5).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+	w.Players[1].Health = 50
+
+	pb := roundTrip(t, w, 15)
+	if !pb.World.GameOver || pb.World.Reason != Timeout || !pb.World.SuddenDeath {
+		t.Fatalf("got game over %t with reason '%s' and sudden death %t, want a timeout after sudden death",
+			pb.World.GameOver, pb.World.Reason, pb.World.SuddenDeath)
+	}
+	if winner := pb.World.Winner(); winner != pb.World.Players[0] {
+		t.Errorf("got winner %v, want player 0", winner)
+	}
+}
@@ -0,0 +1,148 @@
+package arena
+
+import (
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// ReplayMode is the game mode of a replay with its limits, replays without a mode are last man standing
+type ReplayMode struct {
+	Name       string // like --mode
+	ScoreLimit int
+	TickLimit  int64
+	Zones      []Zone // king of the hill
+
+	// shrinking safe zone
+	Center      vector.Vec2
+	Delay       int64
+	MinRadius   float64
+	Damage      int
+	DamageTicks int64
+}
+
+// ModeFrame is the state of the game mode after a single tick
+type ModeFrame struct {
+	Scores map[string]int
+	Flags  []entities.Flag // capture the flag
+	Owners [][]int         // IDs of the tanks holding the king of the hill zones
+}
+
+func recordMode(w *World) ReplayMode {
+	switch mode := w.Mode.(type) {
+	case *Deathmatch:
+		return ReplayMode{Name: "dm", ScoreLimit: mode.KillLimit}
+	case *TimedScore:
+		return ReplayMode{Name: "timed", TickLimit: mode.TickLimit}
+	case *CaptureTheFlag:
+		return ReplayMode{Name: "ctf", ScoreLimit: mode.Captures}
+	case *KingOfTheHill:
+		return ReplayMode{Name: "koth", ScoreLimit: mode.ScoreLimit, TickLimit: mode.TickLimit, Zones: mode.zones}
+	case *SafeZone:
+		return ReplayMode{
+			Name:        "zone",
+			TickLimit:   mode.ShrinkTicks,
+			Center:      mode.Center(w),
+			Delay:       mode.Delay,
+			MinRadius:   mode.MinRadius,
+			Damage:      mode.Damage,
+			DamageTicks: mode.DamageTicks,
+		}
+	}
+	return ReplayMode{Name: "lms"}
+}
+
+func captureMode(w *World) ModeFrame {
+	var f ModeFrame
+	switch mode := w.Mode.(type) {
+	case *Deathmatch:
+		f.Scores = copyScores(mode.Scores)
+	case *TimedScore:
+		f.Scores = copyScores(mode.Scores)
+	case *CaptureTheFlag:
+		f.Scores = copyScores(mode.Scores)
+		f.Flags = mode.Flags()
+	case *KingOfTheHill:
+		f.Scores = copyScores(mode.Scores)
+		for _, zone := range mode.Zones() {
+			f.Owners = append(f.Owners, zone.Owners)
+		}
+	}
+	return f
+}
+
+// gameMode rebuilds the mode of the replay, the flags of capture the flag come from the first frame
+func (rm ReplayMode) gameMode(first ModeFrame) GameMode {
+	switch rm.Name {
+	case "dm":
+		return &Deathmatch{KillLimit: rm.ScoreLimit, Scores: make(killScores)}
+	case "timed":
+		return &TimedScore{TickLimit: rm.TickLimit, Scores: make(killScores)}
+	case "ctf":
+		c := &CaptureTheFlag{Captures: rm.ScoreLimit, Scores: make(map[string]int)}
+		for _, f := range first.Flags {
+			c.flags = append(c.flags, &flag{team: f.Team, base: f.Base, position: f.Base})
+		}
+		return c
+	case "koth":
+		return &KingOfTheHill{
+			ScoreLimit: rm.ScoreLimit,
+			TickLimit:  rm.TickLimit,
+			Scores:     make(map[string]int),
+			zones:      rm.Zones,
+			owners:     make([][]*entities.Player, len(rm.Zones)),
+		}
+	case "zone":
+		center := rm.Center
+		return &SafeZone{
+			Delay:       rm.Delay,
+			ShrinkTicks: rm.TickLimit,
+			MinRadius:   rm.MinRadius,
+			Damage:      rm.Damage,
+			DamageTicks: rm.DamageTicks,
+			center:      &center,
+		}
+	}
+	return &LastManStanding{}
+}
+
+// applyMode puts the recorded state into the playback's game mode
+func (pb *Playback) applyMode(f ModeFrame) {
+	w := pb.World
+	switch mode := w.Mode.(type) {
+	case *Deathmatch:
+		mode.Scores = killScores(copyScores(f.Scores))
+	case *TimedScore:
+		mode.Scores = killScores(copyScores(f.Scores))
+	case *CaptureTheFlag:
+		mode.Scores = copyScores(f.Scores)
+		for i, rf := range f.Flags {
+			if i >= len(mode.flags) {
+				break
+			}
+			mode.flags[i].position = rf.Position
+			mode.flags[i].carrier = nil
+			if rf.Carrier >= 0 {
+				mode.flags[i].carrier = w.Players[rf.Carrier]
+			}
+		}
+	case *KingOfTheHill:
+		mode.Scores = copyScores(f.Scores)
+		for i, owners := range f.Owners {
+			if i >= len(mode.owners) {
+				break
+			}
+			mode.owners[i] = nil
+			for _, id := range owners {
+				mode.owners[i] = append(mode.owners[i], w.Players[id])
+			}
+		}
+	}
+}
+
+func copyScores(scores map[string]int) map[string]int {
+	c := make(map[string]int, len(scores))
+	for s, score := range scores {
+		c[s] = score
+	}
+	return c
+}
@@ -0,0 +1,98 @@
+package arena
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Rules hold all tuning values of a match
+type Rules struct {
+	CollisionDamage      int     `json:"collisionDamage"` // how much health does a player loose on colisions
+	CannonCooldown       int     `json:"cannonCooldown"`  // number ticks
+	ShellDamage          int     `json:"shellDamage"`
+	ShellSpeed           float64 `json:"shellSpeed"`
+	MinFirePower         float64 `json:"minFirePower"`
+	MaxFirePower         float64 `json:"maxFirePower"`
+	ViewRange            float64 `json:"viewRange"`
+	FieldOfView          float64 `json:"fieldOfView"` // degrees around the heading, 0 sees all around
+	MaxSpeed             float64 `json:"maxSpeed"`
+	MaxTurnPerTick       float64 `json:"maxTurnPerTick"`
+	MaxTurretTurnPerTick float64 `json:"maxTurretTurnPerTick"`
+	Acceleration         float64 `json:"acceleration"`
+	Friction             float64 `json:"friction"`
+	RespawnWaitTime      int     `json:"respawnWaitTime"` // number ticks
+	Mass                 float64 `json:"mass"`
+	Health               int     `json:"health"`
+	Energy               float64 `json:"energy"`
+	EnergyRegen          float64 `json:"energyRegen"`     // energy gained per tick
+	ShotEnergy           float64 `json:"shotEnergy"`      // energy a shot costs
+	HitEnergyRefund      float64 `json:"hitEnergyRefund"` // energy the shooter gets back on a hit
+	TankRadius           float64 `json:"tankRadius"`      // 60% of the half width of the tank sprite
+	ShellRadius          float64 `json:"shellRadius"`     // half width of the shell sprite
+	ImpactScaling        float64 `json:"impactScaling"`   // ToDo: Tweak this magic number a bit more
+	FriendlyFire         bool    `json:"friendlyFire"`    // shells hurt teammates
+	MaxMessageBytes      int     `json:"maxMessageBytes"` // radio payload a bot may send per tick
+}
+
+func DefaultRules() Rules {
+	return Rules{
+		CollisionDamage:      0,
+		CannonCooldown:       60,
+		ShellDamage:          15,
+		ShellSpeed:           30.0,
+		MinFirePower:         0.5,
+		MaxFirePower:         3,
+		ViewRange:            2500,
+		FieldOfView:          0,
+		MaxSpeed:             25.0,
+		MaxTurnPerTick:       2.0,
+		MaxTurretTurnPerTick: 5.0,
+		Acceleration:         0.05,
+		Friction:             0.05 * 4,
+		RespawnWaitTime:      180,
+		Mass:                 10,
+		Health:               100,
+		Energy:               100,
+		EnergyRegen:          0.1,
+		ShotEnergy:           10,
+		HitEnergyRefund:      5,
+		TankRadius:           153.6,
+		ShellRadius:          146.0,
+		ImpactScaling:        .15,
+		FriendlyFire:         false,
+		MaxMessageBytes:      256,
+	}
+}
+
+// LoadRules reads a JSON rules file, values missing in the file keep their default
+func LoadRules(path string) (Rules, error) {
+	rules := DefaultRules()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return rules, err
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rules); err != nil {
+		return rules, err
+	}
+	return rules, rules.Validate()
+}
+
+// Validate rejects rules the simulation can't work with
+func (r Rules) Validate() error {
+	if r.MinFirePower <= 0 || r.MaxFirePower <= 0 {
+		return fmt.Errorf("fire power has to be positive, got %g to %g", r.MinFirePower, r.MaxFirePower)
+	}
+	if r.MinFirePower > r.MaxFirePower {
+		return fmt.Errorf("minFirePower %g is above maxFirePower %g", r.MinFirePower, r.MaxFirePower)
+	}
+	if r.ShellSpeed <= 0 {
+		return fmt.Errorf("shellSpeed has to be positive, got %g", r.ShellSpeed)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package arena
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadRules(t *testing.T) {
+	partial := DefaultRules()
+	partial.ShellDamage = 50
+
+	var tests = []struct {
+		name    string
+		content string
+		want    Rules
+		wantErr bool
+	}{
+		{"empty", `{}`, DefaultRules(), false},
+		{"partial", `{"shellDamage": 50}`, partial, false},
+		{"unknown value", `{"shellDamge": 50}`, Rules{}, true},
+		{"invalid json", `{`, Rules{}, true},
+		{"min above max fire power", `{"minFirePower": 4, "maxFirePower": 2}`, Rules{}, true},
+		{"no fire power", `{"minFirePower": 0}`, Rules{}, true},
+		{"standing shells", `{"shellSpeed": 0}`, Rules{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			rules, err := LoadRules(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got no error, want one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error: %s", err)
+			}
+			if rules != tt.want {
+				t.Errorf("got %+v, want %+v", rules, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultRulesFile(t *testing.T) {
+	rules, err := LoadRules("../rules/default.json")
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if rules != DefaultRules() {
+		t.Errorf("rules/default.json differs from the defaults: got %+v, want %+v", rules, DefaultRules())
+	}
+}
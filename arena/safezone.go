@@ -0,0 +1,108 @@
+package arena
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/physics"
+	"github.com/gentoomaniac/go-arena/vector"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultSafeZoneDelay       = 60 * 30
+	defaultSafeZoneTicks       = 60 * 60 * 2
+	defaultSafeZoneMinRadius   = 600
+	defaultSafeZoneDamage      = 1
+	defaultSafeZoneDamageTicks = 6
+)
+
+// NewSafeZone creates a last man standing match in a shrinking safe zone, 0 for the ticks it
+// takes the zone to shrink uses the default
+func NewSafeZone(m *Map, shrinkTicks int64) *SafeZone {
+	if shrinkTicks <= 0 {
+		shrinkTicks = defaultSafeZoneTicks
+	}
+	return &SafeZone{
+		Delay:       defaultSafeZoneDelay,
+		ShrinkTicks: shrinkTicks,
+		MinRadius:   defaultSafeZoneMinRadius,
+		Damage:      defaultSafeZoneDamage,
+		DamageTicks: defaultSafeZoneDamageTicks,
+		center:      m.SafeZoneCenter,
+	}
+}
+
+// SafeZone is last man standing where the safe zone starts to contract toward its center after
+// Delay ticks until it reaches MinRadius. Tanks outside of it lose Damage health every DamageTicks.
+type SafeZone struct {
+	LastManStanding
+	Delay       int64
+	ShrinkTicks int64
+	MinRadius   float64
+	Damage      int
+	DamageTicks int64
+	center      *vector.Vec2
+}
+
+// Center returns where the zone shrinks to. Without a center on the map it's a random point in
+// the middle of the map derived from the seed, so it doesn't matter when it's asked for first.
+func (s *SafeZone) Center(w *World) vector.Vec2 {
+	if s.center == nil {
+		rng := rand.New(rand.NewSource(w.Seed))
+		s.center = &vector.Vec2{
+			X: float64(w.Map.Width) * (.25 + rng.Float64()/2),
+			Y: float64(w.Map.Height) * (.25 + rng.Float64()/2),
+		}
+	}
+	return *s.center
+}
+
+// Radius returns the radius of the zone in the current tick, it starts out covering the whole map
+func (s *SafeZone) Radius(w *World) float64 {
+	center := s.Center(w)
+	start := math.Hypot(math.Max(center.X, float64(w.Map.Width)-center.X), math.Max(center.Y, float64(w.Map.Height)-center.Y))
+	if start < s.MinRadius {
+		return start
+	}
+
+	shrunk := math.Min(1, math.Max(0, float64(w.Tick-s.Delay)/float64(s.ShrinkTicks)))
+	return start - (start-s.MinRadius)*shrunk
+}
+
+// Zone returns the current state of the safe zone
+func (s *SafeZone) Zone(w *World) entities.SafeZone {
+	return entities.SafeZone{
+		Center:       s.Center(w),
+		Radius:       s.Radius(w),
+		TargetRadius: s.MinRadius,
+		Damage:       s.Damage,
+		DamageTicks:  s.DamageTicks,
+	}
+}
+
+func (s *SafeZone) OnTick(w *World) {
+	if s.DamageTicks <= 0 || w.Tick%s.DamageTicks != 0 {
+		return
+	}
+
+	center, radius := s.Center(w), s.Radius(w)
+	for _, p := range w.Players {
+		if p.State != entities.Alive || physics.Distance(p.Position, center) <= radius {
+			continue
+		}
+
+		p.Health -= s.Damage
+		w.event(p, entities.Event{Type: entities.OutsideZone, Tank: -1, Damage: s.Damage})
+		if p.Health <= 0 {
+			w.die(p, nil)
+			log.Info().Int64("tick", w.Tick).Str("name", p.Name).Msg("died outside of the safe zone")
+		}
+	}
+}
+
+func (s *SafeZone) Observe(w *World, p *entities.Player, input *entities.AIInput) {
+	zone := s.Zone(w)
+	input.SafeZone = &zone
+}
@@ -0,0 +1,83 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+func TestSafeZoneRadius(t *testing.T) {
+	m := testMap()
+	m.SafeZoneCenter = &vector.Vec2{X: 3200, Y: 3200}
+	zone := NewSafeZone(m, 100)
+	zone.Delay = 50
+	w := NewWorld(m).WithMode(zone)
+
+	var tests = []struct {
+		tick   int64
+		radius float64
+	}{
+		{0, 3200 * 1.4142135623730951},
+		{50, 3200 * 1.4142135623730951},
+		{100, (3200*1.4142135623730951 + defaultSafeZoneMinRadius) / 2},
+		{150, defaultSafeZoneMinRadius},
+		{1000, defaultSafeZoneMinRadius},
+	}
+	for _, tt := range tests {
+		w.Tick = tt.tick
+		if radius := zone.Radius(w); radius-tt.radius > 1e-9 || tt.radius-radius > 1e-9 {
+			t.Errorf("got radius %f at tick %d, want %f", radius, tt.tick, tt.radius)
+		}
+	}
+}
+
+func TestSafeZoneRandomCenter(t *testing.T) {
+	m := testMap()
+	a := NewWorld(m).WithSeed(42)
+	b := NewWorld(m).WithSeed(42)
+	b.rng.Int63()
+
+	if NewSafeZone(m, 0).Center(a) != NewSafeZone(m, 0).Center(b) {
+		t.Errorf("got different centers for the same seed")
+	}
+	center := NewSafeZone(m, 0).Center(a)
+	if !(center.X >= 1600 && center.X <= 4800 && center.Y >= 1600 && center.Y <= 4800) {
+		t.Errorf("got center %s, want it in the middle of the map", center)
+	}
+}
+
+func TestSafeZoneDamage(t *testing.T) {
+	m := testMap()
+	m.SafeZoneCenter = &vector.Vec2{X: 3200, Y: 3200}
+	zone := NewSafeZone(m, 1)
+	zone.Delay = 0
+	bots := []*recordingBot{{}, {}}
+	w := NewWorld(m).WithMode(zone).WithBots([]entities.AI{bots[0], bots[1]})
+	w.Players[0].Position = vector.Vec2{X: 3200, Y: 3300}
+	w.Players[1].Position = vector.Vec2{X: 500, Y: 500}
+	w.Players[1].Health = zone.Damage + 1
+
+	// damage is only dealt every DamageTicks, the event shows up in the next tick
+	w.Tick = zone.DamageTicks
+	w.Step()
+	w.Step()
+	if w.Players[1].Health != 1 {
+		t.Errorf("got health %d outside of the zone, want 1", w.Players[1].Health)
+	}
+	if !hasEvent(bots[1].events, entities.Event{Type: entities.OutsideZone, Tank: -1, Damage: zone.Damage}) {
+		t.Errorf("got events %v, want %s", bots[1].events, entities.OutsideZone)
+	}
+
+	w.Tick = 2 * zone.DamageTicks
+	w.Step()
+	if w.Players[0].Health != w.Players[0].MaxHealth {
+		t.Errorf("got health %d inside of the zone, want %d", w.Players[0].Health, w.Players[0].MaxHealth)
+	}
+	if w.Players[1].State != entities.Dead {
+		t.Errorf("got state %v outside of the zone, want dead", w.Players[1].State)
+	}
+	if !w.GameOver || w.Winner() != w.Players[0] {
+		t.Errorf("got winner %v, want the tank in the zone", w.Winner())
+	}
+}
@@ -0,0 +1,48 @@
+package arena
+
+import (
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/physics"
+	"github.com/gentoomaniac/go-arena/vector"
+	"github.com/rs/zerolog/log"
+)
+
+func remove(s []*entities.Shell, i int) []*entities.Shell {
+	if i >= len(s) || i < 0 {
+		return s
+	}
+
+	s[i] = s[len(s)-1]
+	return s[:len(s)-1]
+}
+
+func (w *World) updateShells() {
+	// calculate shells
+	shells := w.Shells[:0]
+	for _, s := range w.Shells {
+		collisionPoint := vector.Vec2{X: s.Position.X + s.Movement.X, Y: s.Position.Y + s.Movement.Y}
+		if collisionPoint.X < 0 || collisionPoint.X > float64(w.Map.Width) ||
+			collisionPoint.Y < 0 || collisionPoint.Y > float64(w.Map.Height) {
+			w.event(s.Source, entities.Event{Type: entities.ShellMissed, Tank: -1})
+			continue
+		}
+		if object := w.obstacleAt(collisionPoint); object != nil {
+			w.event(s.Source, entities.Event{Type: entities.ShellMissed, Tank: -1, Object: object.Name})
+			log.Debug().Int64("tick", w.Tick).Str("source", s.Source.Name).Str("object", object.Name).Msg("shell hit object")
+			continue
+		}
+
+		s.Position = collisionPoint
+		shells = append(shells, s)
+	}
+	w.Shells = shells
+}
+
+func (w *World) obstacleAt(point vector.Vec2) *Obstacle {
+	for i := range w.Map.Obstacles {
+		if physics.PointInRectangle(point, w.Map.Obstacles[i].Box) {
+			return &w.Map.Obstacles[i]
+		}
+	}
+	return nil
+}
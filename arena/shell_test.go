@@ -0,0 +1,29 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+func TestShellHitsObstacle(t *testing.T) {
+	m := testMap()
+	m.Obstacles = []Obstacle{{Name: "wall", Box: vector.Rect(1500, 0, 1700, 6400)}}
+
+	w := NewWorld(m)
+	shell := entities.NewShell()
+	shell.Source = &entities.Player{Name: "shooter"}
+	shell.Position = vector.Vec2{X: 1450, Y: 1000}
+	shell.Movement = vector.Vec2{X: DefaultRules().ShellSpeed}
+	w.Shells = append(w.Shells, shell)
+
+	w.updateShells()
+	if len(w.Shells) != 1 {
+		t.Fatalf("shell vanished before reaching the wall")
+	}
+	w.updateShells()
+	if len(w.Shells) != 0 {
+		t.Errorf("shell passed through the wall")
+	}
+}
@@ -0,0 +1,55 @@
+package arena
+
+import (
+	"fmt"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+// WithTeams assigns the bots of WithBots to teams by index, bots without a team fight on their own.
+// It has to be called before WithBots.
+func (w *World) WithTeams(teams []string) *World {
+	w.teams = teams
+	return w
+}
+
+// Side is what a player fights for: its team or, without one, only itself
+func Side(p *entities.Player) string {
+	if p.Team != "" {
+		return p.Team
+	}
+	return fmt.Sprintf("#%d", p.ID)
+}
+
+// teammates tells if a and b fight on the same side
+func teammates(a, b *entities.Player) bool {
+	return a != b && a.Team != "" && a.Team == b.Team
+}
+
+// aliveSides returns the teams and lone players still in the match
+func (w *World) aliveSides() map[string]bool {
+	sides := make(map[string]bool)
+	for _, p := range w.alivePlayers() {
+		sides[Side(p)] = true
+	}
+	return sides
+}
+
+// WinningTeam returns the team that won the match, "" if no team won
+func (w *World) WinningTeam() string {
+	if winners := w.Winners(); len(winners) > 0 {
+		return winners[0].Team
+	}
+	return ""
+}
+
+// playersOf returns all players fighting for the side, dead or alive
+func (w *World) playersOf(s string) []*entities.Player {
+	var players []*entities.Player
+	for _, p := range w.Players {
+		if Side(p) == s {
+			players = append(players, p)
+		}
+	}
+	return players
+}
@@ -0,0 +1,63 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+func TestTeamGameOver(t *testing.T) {
+	var tests = []struct {
+		name     string
+		teams    []string
+		dead     []int
+		gameOver bool
+		winner   string
+	}{
+		{"everyone alive", []string{"a", "a", "b", "b"}, nil, false, ""},
+		{"one of each team left", []string{"a", "a", "b", "b"}, []int{0, 2}, false, ""},
+		{"team a left", []string{"a", "a", "b", "b"}, []int{2, 3}, true, "a"},
+		{"lone bot against team", []string{"a", "a", ""}, []int{0}, false, ""},
+		{"lone bot wins", []string{"a", "a", ""}, []int{0, 1}, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bots := make([]entities.AI, len(tt.teams))
+			for i := range bots {
+				bots[i] = &constantBot{}
+			}
+			w := NewWorld(testMap()).WithTeams(tt.teams).WithBots(bots)
+			for _, i := range tt.dead {
+				w.Players[i].State = entities.Dead
+			}
+
+			w.isGameOver()
+			if w.GameOver != tt.gameOver {
+				t.Errorf("got game over %t, want %t", w.GameOver, tt.gameOver)
+			}
+			if team := w.WinningTeam(); team != tt.winner {
+				t.Errorf("got winning team '%s', want '%s'", team, tt.winner)
+			}
+		})
+	}
+}
+
+func TestFriendlyFire(t *testing.T) {
+	for _, friendlyFire := range []bool{false, true} {
+		rules := DefaultRules()
+		rules.FriendlyFire = friendlyFire
+		w := NewWorld(testMap()).WithRules(rules).WithTeams([]string{"a", "a"}).WithBots([]entities.AI{&constantBot{}, &constantBot{}})
+
+		shell := entities.NewShell()
+		shell.Source = w.Players[0]
+		shell.Damage = 15
+		shell.Position = w.Players[1].Position
+		w.Shells = append(w.Shells, shell)
+		w.Step()
+
+		if hit := w.Players[1].Health < w.Players[1].MaxHealth; hit != friendlyFire {
+			t.Errorf("friendly fire %t: got teammate hit %t", friendlyFire, hit)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package arena
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/rs/zerolog/log"
+)
+
+type Format string
+
+const (
+	Pairs      Format = "pairs" // every bot fights every other bot one on one
+	FreeForAll Format = "ffa"   // all bots fight in the same match
+	AllFormats Format = "all"
+)
+
+// Standing sums up the results of a bot over all matches of a tournament
+type Standing struct {
+	Name       string
+	Matches    int
+	Wins       int
+	Kills      int
+	Deaths     int
+	TicksAlive int64
+}
+
+func NewTournament(m *Map) *Tournament {
+	return &Tournament{Map: m, Rules: DefaultRules(), Rounds: 1, Format: AllFormats}
+}
+
+// Tournament runs headless round-robin matches between a pool of bots
+type Tournament struct {
+	Map       *Map
+	Rules     Rules
+	Rounds    int
+	Format    Format
+	MaxTicks  int64
+	Respawns  int
+	Seed      int64
+	bots      []BotFactory
+	Standings []*Standing
+
+	SuddenDeath   int64 // ticks of sudden death after MaxTicks
+	computeBudget time.Duration
+	maxOverruns   int
+	retired       map[int]bool // bots stuck in Compute, they lose their remaining matches
+}
+
+// closeGrace is how many compute budgets the bots get to return from late calls after a match
+const closeGrace = 10
+
+// AddBot adds a bot to the pool, every match gets a new instance of it
+func (t *Tournament) AddBot(name string, bot BotFactory) *Tournament {
+	t.bots = append(t.bots, bot)
+	t.Standings = append(t.Standings, &Standing{Name: name})
+	return t
+}
+
+func (t *Tournament) WithRules(rules Rules) *Tournament {
+	t.Rules = rules
+	return t
+}
+
+func (t *Tournament) WithRounds(rounds int) *Tournament {
+	t.Rounds = rounds
+	return t
+}
+
+func (t *Tournament) WithFormat(format Format) *Tournament {
+	t.Format = format
+	return t
+}
+
+// WithMaxTicks ends matches after the given number of ticks, the bot with the most health and
+// then kills wins. 0 means no limit.
+func (t *Tournament) WithMaxTicks(ticks int64) *Tournament {
+	t.MaxTicks = ticks
+	return t
+}
+
+// WithSuddenDeath plays the given number of ticks of sudden death when a match reaches MaxTicks
+func (t *Tournament) WithSuddenDeath(ticks int64) *Tournament {
+	t.SuddenDeath = ticks
+	return t
+}
+
+func (t *Tournament) WithRespawns(respawns int) *Tournament {
+	t.Respawns = respawns
+	return t
+}
+
+func (t *Tournament) WithComputeBudget(budget time.Duration, maxOverruns int) *Tournament {
+	t.computeBudget = budget
+	t.maxOverruns = maxOverruns
+	return t
+}
+
+func (t *Tournament) WithSeed(seed int64) *Tournament {
+	t.Seed = seed
+	return t
+}
+
+// pairings returns the indices of the bots taking part in each match of a round
+func (t *Tournament) pairings() [][]int {
+	var matches [][]int
+	if t.Format == Pairs || t.Format == AllFormats {
+		for i := range t.bots {
+			for j := i + 1; j < len(t.bots); j++ {
+				matches = append(matches, []int{i, j})
+			}
+		}
+	}
+	if (t.Format == FreeForAll || t.Format == AllFormats) && len(t.bots) > 2 {
+		all := make([]int, len(t.bots))
+		for i := range all {
+			all[i] = i
+		}
+		matches = append(matches, all)
+	}
+	return matches
+}
+
+// Run plays all matches and returns the standings ordered by wins and kills
+func (t *Tournament) Run() ([]*Standing, error) {
+	pairings := t.pairings()
+	players := 0
+	for _, match := range pairings {
+		if len(match) > players {
+			players = len(match)
+		}
+	}
+	if err := t.Map.Validate(players); err != nil {
+		return nil, err
+	}
+
+	t.retired = map[int]bool{}
+	rng := rand.New(rand.NewSource(t.Seed))
+	for round := 0; round < t.Rounds; round++ {
+		for _, match := range pairings {
+			if err := t.runMatch(match, rng.Int63()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	standings := append([]*Standing{}, t.Standings...)
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Wins != standings[j].Wins {
+			return standings[i].Wins > standings[j].Wins
+		}
+		return standings[i].Kills > standings[j].Kills
+	})
+	return standings, nil
+}
+
+func (t *Tournament) runMatch(match []int, seed int64) error {
+	var playing []int
+	for _, index := range match {
+		if t.retired[index] {
+			t.Standings[index].Matches++
+			continue
+		}
+		playing = append(playing, index)
+	}
+	if len(playing) == 0 {
+		return nil
+	}
+	match = playing
+
+	bots := make([]entities.AI, 0, len(match))
+	for _, index := range match {
+		ai, err := t.bots[index]()
+		if err != nil {
+			CloseBots(bots)
+			return fmt.Errorf("could not start bot '%s': %w", t.Standings[index].Name, err)
+		}
+		bots = append(bots, ai)
+	}
+
+	w := NewWorld(t.Map).
+		WithRules(t.Rules).
+		WithSeed(seed).
+		WithRespawns(t.Respawns).
+		WithComputeBudget(t.computeBudget, t.maxOverruns).
+		WithTickLimit(t.MaxTicks, t.SuddenDeath).
+		WithBots(bots)
+	for !w.GameOver {
+		w.Step()
+	}
+	// stopping the processes ends their late calls, plugins can't be stopped and get some time
+	CloseBots(bots)
+	for _, id := range w.Close(closeGrace * t.computeBudget) {
+		index := match[id]
+		t.retired[index] = true
+		log.Error().Str("name", t.Standings[index].Name).Msg("bot doesn't return from compute, it loses its remaining matches")
+	}
+
+	winner := w.Winner()
+	for i, index := range match {
+		p := w.Players[i]
+		s := t.Standings[index]
+		s.Matches++
+		s.Kills += p.Kills
+		s.Deaths += p.Deaths
+		s.TicksAlive += p.TicksAlive
+		if p == winner {
+			s.Wins++
+		}
+	}
+
+	winnerName := "draw"
+	if winner != nil {
+		winnerName = t.Standings[match[winner.ID]].Name
+	}
+	log.Info().Int64("seed", seed).Int64("ticks", w.Tick).Int("players", len(match)).Str("winner", winnerName).Str("reason", string(w.Reason)).Msg("match finished")
+	return nil
+}
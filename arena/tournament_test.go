@@ -0,0 +1,177 @@
+package arena
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/gentoomaniac/go-arena/entities"
+)
+
+// reuse hands out the same instance of the bot for every match
+func reuse(ai entities.AI) BotFactory {
+	return func() (entities.AI, error) { return ai, nil }
+}
+
+func TestPairings(t *testing.T) {
+	var tests = []struct {
+		name   string
+		format Format
+		bots   int
+		want   int
+	}{
+		{"pairs of two", Pairs, 2, 1},
+		{"pairs of four", Pairs, 4, 6},
+		{"free for all", FreeForAll, 4, 1},
+		{"free for all needs three bots", FreeForAll, 2, 0},
+		{"all formats", AllFormats, 3, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := NewTournament(testMap()).WithFormat(tt.format)
+			for i := 0; i < tt.bots; i++ {
+				tournament.AddBot("bot", reuse(&constantBot{}))
+			}
+			if got := len(tournament.pairings()); got != tt.want {
+				t.Errorf("got %d matches, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTournamentRun(t *testing.T) {
+	standings, err := NewTournament(testMap()).
+		WithRounds(2).
+		WithFormat(AllFormats).
+		WithMaxTicks(300).
+		WithSeed(1).
+		AddBot("a", reuse(&randomBot{})).
+		AddBot("b", reuse(&randomBot{})).
+		AddBot("c", reuse(&constantBot{entities.AIOutput{}})).
+		Run()
+
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(standings) != 3 {
+		t.Fatalf("got %d standings, want 3", len(standings))
+	}
+	for _, s := range standings {
+		// every bot plays two pairings and the free-for-all per round
+		if s.Matches != 6 {
+			t.Errorf("%s: got %d matches, want 6", s.Name, s.Matches)
+		}
+	}
+	for i := 1; i < len(standings); i++ {
+		if standings[i].Wins > standings[i-1].Wins {
+			t.Errorf("standings are not ordered by wins")
+		}
+	}
+}
+
+func TestTournamentValidatesMap(t *testing.T) {
+	m := testMap()
+	m.SpawnPoints = m.SpawnPoints[:2]
+
+	_, err := NewTournament(m).
+		WithFormat(FreeForAll).
+		AddBot("a", reuse(&constantBot{})).
+		AddBot("b", reuse(&constantBot{})).
+		AddBot("c", reuse(&constantBot{})).
+		Run()
+	if err == nil {
+		t.Errorf("got no error for a free-for-all with too few spawn points")
+	}
+}
+
+// busyBot sleeps in Compute and keeps state between Init and Compute
+type busyBot struct {
+	info entities.ArenaInfo
+}
+
+func (b *busyBot) Init(_ *rand.Rand, info entities.ArenaInfo) { b.info = info }
+func (b *busyBot) Name() string                               { return "busy" }
+func (b *busyBot) Compute(entities.AIInput) entities.AIOutput {
+	time.Sleep(3 * time.Millisecond)
+	return entities.AIOutput{Speed: b.info.Rules.MaxSpeed}
+}
+
+// late calls of a match must be over before the bot gets initialised for the next one, run with -race
+func TestTournamentWaitsForLateCompute(t *testing.T) {
+	_, err := NewTournament(testMap()).
+		WithRounds(3).
+		WithFormat(Pairs).
+		WithMaxTicks(2).
+		WithComputeBudget(time.Millisecond, 0).
+		AddBot("a", reuse(&busyBot{})).
+		AddBot("b", reuse(&constantBot{})).
+		Run()
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+}
+
+// a bot that never returns from Compute must not hang the tournament
+func TestTournamentStuckBot(t *testing.T) {
+	stuck := &slowBot{release: make(chan struct{})}
+	defer close(stuck.release)
+	tournament := NewTournament(testMap()).
+		WithRounds(2).
+		WithFormat(Pairs).
+		WithMaxTicks(300).
+		WithComputeBudget(time.Millisecond, 3).
+		AddBot("stuck", reuse(stuck)).
+		AddBot("a", reuse(&constantBot{})).
+		AddBot("b", reuse(&constantBot{}))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := tournament.Run()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("got error: %s", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("tournament didn't finish")
+	}
+
+	for _, s := range tournament.Standings {
+		if s.Matches != 4 {
+			t.Errorf("%s: got %d matches, want 4", s.Name, s.Matches)
+		}
+	}
+	if s := tournament.Standings[0]; s.Wins != 0 {
+		t.Errorf("got %d wins for the stuck bot, want 0", s.Wins)
+	}
+	// it was only asked in its first match
+	stuck.mu.Lock()
+	defer stuck.mu.Unlock()
+	if stuck.calls != 1 {
+		t.Errorf("got %d calls of the stuck bot, want 1", stuck.calls)
+	}
+}
+
+func TestTournamentTimeout(t *testing.T) {
+	rules := DefaultRules()
+	rules.CollisionDamage = 1
+	rules.Health = 5000
+
+	// the driving bot hits a wall and loses health but can't die before the tick limit
+	tournament := NewTournament(testMap()).
+		WithRules(rules).
+		WithFormat(Pairs).
+		WithMaxTicks(600).
+		WithSeed(1).
+		AddBot("driving", reuse(&constantBot{entities.AIOutput{Speed: rules.MaxSpeed}})).
+		AddBot("standing", reuse(&constantBot{}))
+	if _, err := tournament.Run(); err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if s := tournament.Standings[1]; s.Wins != 1 {
+		t.Errorf("got %d wins for the undamaged bot, want 1", s.Wins)
+	}
+}
@@ -0,0 +1,226 @@
+package arena
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+func NewWorld(m *Map) *World {
+	return &World{
+		Map:       m,
+		Rules:     DefaultRules(),
+		Mode:      &LastManStanding{},
+		rng:       rand.New(rand.NewSource(0)),
+		pending:   make(map[int]chan computeResult),
+		sightings: make(map[int]map[int]entities.Enemy),
+	}
+}
+
+// World is the headless simulation of a single match
+type World struct {
+	Map      *Map
+	Rules    Rules
+	Mode     GameMode
+	Players  []*entities.Player
+	Shells   []*entities.Shell
+	Tick     int64
+	GameOver bool
+	Reason   Reason // why the match ended
+	Seed     int64
+	respawns int
+	teams    []string
+	rng      *rand.Rand
+
+	// tick limit and sudden death, SuddenDeath is set once the match went into it
+	SuddenDeath      bool
+	maxTicks         int64
+	suddenDeathTicks int64
+	timedOut         bool
+
+	// last known state of the enemies per observing player
+	sightings map[int]map[int]entities.Enemy
+	// team radio, messages sent in a tick are received in the next one
+	outbox []teamMessage
+	inbox  []teamMessage
+
+	computeBudget time.Duration
+	maxOverruns   int
+	pending       map[int]chan computeResult
+}
+
+// WithRules has to be called before WithBots as the players' stats come from the rules
+func (w *World) WithRules(rules Rules) *World {
+	w.Rules = rules
+	return w
+}
+
+func (w *World) WithMode(mode GameMode) *World {
+	w.Mode = mode
+	return w
+}
+
+func (w *World) WithRespawns(respawns int) *World {
+	w.respawns = respawns
+	return w
+}
+
+// WithComputeBudget limits the time a bot may take per tick, 0 disables the limit.
+// Bots exceeding the budget maxOverruns times get disqualified, 0 never disqualifies.
+func (w *World) WithComputeBudget(budget time.Duration, maxOverruns int) *World {
+	w.computeBudget = budget
+	w.maxOverruns = maxOverruns
+	return w
+}
+
+// WithSeed makes spawn selection, respawns and the bots' random numbers reproducible.
+// It has to be called before WithBots.
+func (w *World) WithSeed(seed int64) *World {
+	w.Seed = seed
+	w.rng = rand.New(rand.NewSource(seed))
+	return w
+}
+
+func removeSpawn(s []vector.Vec2, i int) []vector.Vec2 {
+	if i >= len(s) || i < 0 {
+		return s
+	}
+
+	s[i] = s[len(s)-1]
+	return s[:len(s)-1]
+}
+
+func (w *World) WithBots(bots []entities.AI) *World {
+	spawnPoints := append([]vector.Vec2{}, w.Map.SpawnPoints...)
+	for index, ai := range bots {
+		// every bot gets its own source so it can't influence the match rng
+		name, err := safeInit(ai, rand.New(rand.NewSource(w.rng.Int63())), w.arenaInfo(index))
+
+		spawnIndex := w.rng.Int() % len(spawnPoints)
+		spawnPoint := spawnPoints[spawnIndex]
+		spawnPoints = removeSpawn(spawnPoints, spawnIndex)
+
+		player := &entities.Player{
+			ID:              index,
+			Name:            name,
+			State:           entities.Alive,
+			Position:        spawnPoint,
+			Velocity:        vector.Vec2{},
+			Mass:            w.Rules.Mass,
+			Health:          w.Rules.Health,
+			MaxHealth:       w.Rules.Health,
+			Energy:          w.Rules.Energy,
+			MaxEnergy:       w.Rules.Energy,
+			MaxSpeed:        w.Rules.MaxSpeed,
+			Acceleration:    w.Rules.Acceleration,
+			Friction:        w.Rules.Friction,
+			CollisionRadius: w.Rules.TankRadius,
+			Collided:        false,
+			AI:              ai,
+			MaxRespawns:     w.respawns,
+		}
+		player.Team = w.teamOf(index)
+		if err != nil {
+			if player.Name == "" {
+				player.Name = fmt.Sprintf("Bot %d", index+1)
+			}
+			w.crash(player, err)
+		}
+
+		w.Players = append(w.Players, player)
+	}
+	return w
+}
+
+// Step advances the simulation by one tick
+func (w *World) Step() {
+	if !w.GameOver {
+		// update all player positions
+		for _, p := range w.Players {
+			p.Position.X += p.Velocity.X
+			p.Position.Y += p.Velocity.Y
+		}
+
+		for _, p := range w.Players {
+			w.updatePlayer(p)
+		}
+
+		w.updateShells()
+		w.deliverMessages()
+		w.Mode.OnTick(w)
+
+		for _, p := range w.Players {
+			if p.State == entities.Alive {
+				p.TicksAlive++
+			}
+		}
+		w.Tick++
+	}
+
+	w.isGameOver()
+}
+
+func (w *World) alivePlayers() []*entities.Player {
+	var alive []*entities.Player
+	for _, p := range w.Players {
+		if p.State == entities.Alive || (p.State == entities.Dead && p.NumberRespawns < p.MaxRespawns) {
+			alive = append(alive, p)
+		}
+	}
+	return alive
+}
+
+// Winner returns the player that won the match alone, nil if the match isn't decided or a team won
+func (w *World) Winner() *entities.Player {
+	if winners := w.Winners(); len(winners) == 1 {
+		return winners[0]
+	}
+	return nil
+}
+
+// arenaInfo is what the bot of the player with the given ID learns about the match
+func (w *World) arenaInfo(id int) entities.ArenaInfo {
+	info := entities.ArenaInfo{
+		ID:          id,
+		Team:        w.teamOf(id),
+		Width:       float64(w.Map.Width),
+		Height:      float64(w.Map.Height),
+		Obstacles:   make([]vector.Rectangle, 0, len(w.Map.Obstacles)),
+		SpawnPoints: append([]vector.Vec2{}, w.Map.SpawnPoints...),
+		Rules: entities.ArenaRules{
+			MaxSpeed:             w.Rules.MaxSpeed,
+			MaxTurnPerTick:       w.Rules.MaxTurnPerTick,
+			MaxTurretTurnPerTick: w.Rules.MaxTurretTurnPerTick,
+			ViewRange:            w.Rules.ViewRange,
+			FieldOfView:          w.Rules.FieldOfView,
+			CannonCooldown:       w.Rules.CannonCooldown,
+			ShellDamage:          w.Rules.ShellDamage,
+			ShellSpeed:           w.Rules.ShellSpeed,
+			MinFirePower:         w.Rules.MinFirePower,
+			MaxFirePower:         w.Rules.MaxFirePower,
+			Health:               w.Rules.Health,
+			Energy:               w.Rules.Energy,
+			EnergyRegen:          w.Rules.EnergyRegen,
+			ShotEnergy:           w.Rules.ShotEnergy,
+			CollisionDamage:      w.Rules.CollisionDamage,
+			RespawnWaitTime:      w.Rules.RespawnWaitTime,
+			TankRadius:           w.Rules.TankRadius,
+			FriendlyFire:         w.Rules.FriendlyFire,
+			MaxMessageBytes:      w.Rules.MaxMessageBytes,
+		},
+	}
+	for _, object := range w.Map.Obstacles {
+		info.Obstacles = append(info.Obstacles, object.Box)
+	}
+	return info
+}
+
+func (w *World) teamOf(id int) string {
+	if id < len(w.teams) {
+		return w.teams[id]
+	}
+	return ""
+}
@@ -0,0 +1,230 @@
+package arena
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+type constantBot struct {
+	output entities.AIOutput
+}
+
+func (b *constantBot) Init(*rand.Rand, entities.ArenaInfo) {}
+func (b *constantBot) Name() string                        { return "constant" }
+func (b *constantBot) Compute(entities.AIInput) entities.AIOutput {
+	return b.output
+}
+
+// randomBot drives around using only the rng it was handed
+type randomBot struct {
+	rng *rand.Rand
+}
+
+func (b *randomBot) Init(rng *rand.Rand, _ entities.ArenaInfo) { b.rng = rng }
+func (b *randomBot) Name() string                              { return "random" }
+func (b *randomBot) Compute(entities.AIInput) entities.AIOutput {
+	return entities.AIOutput{
+		Speed:             b.rng.Float64() * DefaultRules().MaxSpeed,
+		OrientationChange: b.rng.Float64() * DefaultRules().MaxTurnPerTick,
+		Shoot:             b.rng.Intn(2) == 0,
+	}
+}
+
+func testMap() *Map {
+	return &Map{
+		Width:  6400,
+		Height: 6400,
+		SpawnPoints: []vector.Vec2{
+			{X: 3200, Y: 900},
+			{X: 900, Y: 3200},
+			{X: 5500, Y: 3200},
+			{X: 3200, Y: 5500},
+		},
+	}
+}
+
+func TestStep(t *testing.T) {
+	var tests = []struct {
+		name     string
+		bots     []entities.AI
+		ticks    int
+		gameOver bool
+	}{
+		{
+			"single bot ends the game",
+			[]entities.AI{&constantBot{}},
+			1,
+			true,
+		},
+		{
+			"idle bots keep playing",
+			[]entities.AI{&constantBot{}, &constantBot{}},
+			100,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld(testMap()).WithBots(tt.bots)
+			for i := 0; i < tt.ticks; i++ {
+				w.Step()
+			}
+			if w.GameOver != tt.gameOver {
+				t.Errorf("got game over '%t', want '%t'", w.GameOver, tt.gameOver)
+			}
+		})
+	}
+}
+
+func TestStepShooting(t *testing.T) {
+	w := NewWorld(testMap()).WithBots([]entities.AI{
+		&constantBot{entities.AIOutput{Speed: 5, Shoot: true}},
+		&constantBot{},
+	})
+
+	w.Step()
+	if len(w.Shells) != 1 {
+		t.Fatalf("got %d shells, want 1", len(w.Shells))
+	}
+	if w.Players[0].CannonCooldown != w.Rules.CannonCooldown {
+		t.Errorf("got cannon cooldown %d, want %d", w.Players[0].CannonCooldown, w.Rules.CannonCooldown)
+	}
+	if w.Tick != 1 {
+		t.Errorf("got tick %d, want 1", w.Tick)
+	}
+}
+
+func TestSeedIsDeterministic(t *testing.T) {
+	simulate := func(seed int64) []vector.Vec2 {
+		w := NewWorld(testMap()).WithSeed(seed).WithRespawns(3).WithBots([]entities.AI{&randomBot{}, &randomBot{}, &randomBot{}})
+		for i := 0; i < 500; i++ {
+			w.Step()
+		}
+		var positions []vector.Vec2
+		for _, p := range w.Players {
+			positions = append(positions, p.Position)
+		}
+		return positions
+	}
+
+	a := simulate(42)
+	b := simulate(42)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("player %d diverged: got '%s' and '%s'", i, a[i], b[i])
+		}
+	}
+}
+
+func TestObstacleCollision(t *testing.T) {
+	m := testMap()
+	m.SpawnPoints = []vector.Vec2{{X: 1000, Y: 1000}}
+	m.Obstacles = []Obstacle{{Name: "wall", Box: vector.Rect(1500, 0, 1700, 6400)}}
+
+	w := NewWorld(m).WithBots([]entities.AI{&constantBot{entities.AIOutput{Speed: DefaultRules().MaxSpeed}}})
+	w.Players[0].Orientation = vector.Vec2{X: 1, Y: 0}
+	w.GameOver = false
+
+	collided := false
+	for i := 0; i < 500; i++ {
+		w.Step()
+		w.GameOver = false
+		collided = collided || w.Players[0].Collided
+	}
+
+	p := w.Players[0]
+	if !collided {
+		t.Errorf("tank never collided with the wall")
+	}
+	if p.Position.X+p.CollisionRadius > 1500+0.001 {
+		t.Errorf("tank drove into the wall: %s", p.Position)
+	}
+}
+
+func TestTurret(t *testing.T) {
+	w := NewWorld(testMap()).WithBots([]entities.AI{
+		&constantBot{entities.AIOutput{TurretChange: 90, Shoot: true}},
+		&constantBot{},
+	})
+
+	w.Step()
+	p := w.Players[0]
+	if p.Turret != w.Rules.MaxTurretTurnPerTick {
+		t.Errorf("got turret angle %f, want it clamped to %f", p.Turret, w.Rules.MaxTurretTurnPerTick)
+	}
+	if len(w.Shells) != 1 {
+		t.Fatalf("got %d shells, want 1", len(w.Shells))
+	}
+	if angle := w.Shells[0].Movement.Angle(); math.Abs(angle-p.TurretOrientation()) > 0.001 {
+		t.Errorf("shell flies at %f degrees, want %f", angle, p.TurretOrientation())
+	}
+}
+
+func TestEnergy(t *testing.T) {
+	w := NewWorld(testMap()).WithBots([]entities.AI{
+		&constantBot{entities.AIOutput{Shoot: true}},
+		&constantBot{},
+	})
+	p := w.Players[0]
+
+	w.Step()
+	if want := p.MaxEnergy - w.Rules.ShotEnergy; math.Abs(p.Energy-want) > 0.001 {
+		t.Errorf("got energy %f after a shot, want %f", p.Energy, want)
+	}
+
+	p.Energy = w.Rules.ShotEnergy / 2
+	p.CannonCooldown = 0
+	w.Step()
+	if len(w.Shells) != 1 {
+		t.Errorf("got %d shells, an empty tank must not fire", len(w.Shells))
+	}
+
+	shell := entities.NewShell()
+	shell.Source = p
+	shell.Power = 1
+	shell.Position = w.Players[1].Position
+	w.Shells = append(w.Shells, shell)
+	before := p.Energy
+	w.Step()
+	if want := before + w.Rules.EnergyRegen + w.Rules.HitEnergyRefund; math.Abs(p.Energy-want) > 0.001 {
+		t.Errorf("got energy %f after a hit, want %f", p.Energy, want)
+	}
+}
+
+// infoBot keeps what it learned about the arena
+type infoBot struct {
+	constantBot
+	info entities.ArenaInfo
+}
+
+func (b *infoBot) Init(_ *rand.Rand, info entities.ArenaInfo) { b.info = info }
+
+func TestArenaInfo(t *testing.T) {
+	m := testMap()
+	m.Obstacles = []Obstacle{{Name: "wall", Box: vector.Rect(1500, 0, 1700, 6400)}}
+	bots := []*infoBot{{}, {}}
+	w := NewWorld(m).WithBots([]entities.AI{bots[0], bots[1]})
+
+	for i, b := range bots {
+		if b.info.ID != i {
+			t.Errorf("bot %d got ID %d", i, b.info.ID)
+		}
+		if b.info.Width != 6400 || b.info.Height != 6400 {
+			t.Errorf("got arena size %fx%f, want 6400x6400", b.info.Width, b.info.Height)
+		}
+		if len(b.info.Obstacles) != 1 || b.info.Obstacles[0] != m.Obstacles[0].Box {
+			t.Errorf("got obstacles %v", b.info.Obstacles)
+		}
+		if len(b.info.SpawnPoints) != len(m.SpawnPoints) {
+			t.Errorf("got %d spawn points, want %d", len(b.info.SpawnPoints), len(m.SpawnPoints))
+		}
+		if b.info.Rules.MaxSpeed != w.Rules.MaxSpeed || b.info.Rules.ViewRange != w.Rules.ViewRange {
+			t.Errorf("got rules %+v", b.info.Rules)
+		}
+	}
+}
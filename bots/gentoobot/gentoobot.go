@@ -7,16 +7,19 @@ import (
 )
 
 type GentooBot struct {
+	rng *rand.Rand
 }
 
-func (g *GentooBot) Init() {}
+func (g *GentooBot) Init(rng *rand.Rand, _ entities.ArenaInfo) {
+	g.rng = rng
+}
 
 func (g *GentooBot) Compute(input entities.AIInput) entities.AIOutput {
 	shoot := false
 	orientation := 0.2
-	speed := float64(15 + rand.Int()%10)
+	speed := float64(15 + g.rng.Int()%10)
 	if input.Collided {
-		orientation = 10 + float64(rand.Int()%5)
+		orientation = 10 + float64(g.rng.Int()%5)
 	}
 
 	if input.CannonReady {
@@ -30,4 +33,6 @@ func (g *GentooBot) Name() string {
 	return "Gentoobot"
 }
 
-var Bot GentooBot
+func NewBot() entities.AI {
+	return &GentooBot{}
+}
@@ -0,0 +1,28 @@
+#!/usr/bin/env python3
+# Example bot talking to go-arena over stdin/stdout, one JSON document per line.
+import json
+import random
+import sys
+
+
+def send(message):
+    sys.stdout.write(json.dumps(message) + "\n")
+    sys.stdout.flush()
+
+
+init = json.loads(sys.stdin.readline())
+rng = random.Random(init["seed"])
+send({"name": "PyBot"})
+
+for line in sys.stdin:
+    state = json.loads(line)
+
+    orientation = 0.5
+    if state["collided"]:
+        orientation = 10 + rng.randint(0, 5)
+
+    enemies = [e for e in state["enemy"] or [] if e["visible"] and e["state"] == 0]
+    if enemies:
+        orientation = min(enemies, key=lambda e: e["distance"])["angle"]
+
+    send({"speed": 10, "orientationChange": orientation, "shoot": state["cannonReady"] and bool(enemies)})
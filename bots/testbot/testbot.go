@@ -10,11 +10,13 @@ import (
 type TestBot struct {
 	orientation float64
 	speed       float64
+	rng         *rand.Rand
 }
 
-func (t *TestBot) Init() {
+func (t *TestBot) Init(rng *rand.Rand, _ entities.ArenaInfo) {
 	t.orientation = 0.3
 	t.speed = 5
+	t.rng = rng
 }
 
 func (t *TestBot) Compute(input entities.AIInput) entities.AIOutput {
@@ -23,7 +25,7 @@ func (t *TestBot) Compute(input entities.AIInput) entities.AIOutput {
 	speed := t.speed
 
 	if input.Collided {
-		orientation = -10 - float64(rand.Int()%10)
+		orientation = -10 - float64(t.rng.Int()%10)
 		speed = 5
 	}
 
@@ -31,19 +33,17 @@ func (t *TestBot) Compute(input entities.AIInput) entities.AIOutput {
 		speed = 0
 	}
 
-	if len(input.Enemy) > 0 {
-		enemy := input.Enemy[0]
-		for _, e := range input.Enemy[1:] {
-			if e.Distance < enemy.Distance {
-				enemy = e
-			}
-		}
-		if enemy.State == entities.Alive {
-			orientation = enemy.Angle
-			shoot = true
-			speed = 10
+	var enemy *entities.Enemy
+	for _, e := range input.Enemy {
+		if e.Visible && (enemy == nil || e.Distance < enemy.Distance) {
+			enemy = e
 		}
 	}
+	if enemy != nil && enemy.State == entities.Alive {
+		orientation = enemy.Angle
+		shoot = true
+		speed = 10
+	}
 
 	return entities.AIOutput{
 		Speed:             speed,
@@ -53,7 +53,9 @@ func (t *TestBot) Compute(input entities.AIInput) entities.AIOutput {
 }
 
 func (t TestBot) Name() string {
-	return fmt.Sprintf("TestBot %d", rand.Int()%10)
+	return fmt.Sprintf("TestBot %d", t.rng.Int()%10)
 }
 
-var Bot TestBot
+func NewBot() entities.AI {
+	return &TestBot{}
+}
@@ -1,28 +1,47 @@
 package entities
 
-import "github.com/gentoomaniac/go-arena/vector"
+import (
+	"math/rand"
+
+	"github.com/gentoomaniac/go-arena/vector"
+)
 
 type AIInput struct {
-	Position         vector.Vec2
-	CurrentSpeed     float64
-	TargetSpeed      float64
-	MaxSpeed         float64
-	Orientation      float64
-	Collided         bool
-	CollidedWithTank bool
-	Hit              bool
-	CannonReady      bool
-	Enemy            []*Enemy
+	Position          vector.Vec2   `json:"position"`
+	CurrentSpeed      float64       `json:"currentSpeed"`
+	TargetSpeed       float64       `json:"targetSpeed"`
+	MaxSpeed          float64       `json:"maxSpeed"`
+	Orientation       float64       `json:"orientation"`
+	Turret            float64       `json:"turret"`            // turret angle relative to the orientation
+	TurretOrientation float64       `json:"turretOrientation"` // absolute angle the cannon points to
+	Collided          bool          `json:"collided"`
+	CollidedWithTank  bool          `json:"collidedWithTank"`
+	Hit               bool          `json:"hit"`
+	CannonReady       bool          `json:"cannonReady"`
+	Energy            float64       `json:"energy"`
+	MaxEnergy         float64       `json:"maxEnergy"`
+	Enemy             []*Enemy      `json:"enemy"`
+	Projectiles       []*Projectile `json:"projectiles"`
+	Events            []Event       `json:"events"`
+	ReceivedMessages  []Message     `json:"receivedMessages"`
+	Flags             []Flag        `json:"flags"`              // only set in capture the flag matches
+	Zones             []Zone        `json:"zones"`              // only set in king of the hill matches
+	SafeZone          *SafeZone     `json:"safeZone,omitempty"` // only set in shrinking safe zone matches
 }
 
 type AIOutput struct {
-	Speed             float64 `json:"speed"`
-	OrientationChange float64 `json:"orientationChange"`
-	Shoot             bool    `json:"shoot"`
+	Speed             float64  `json:"speed"`
+	OrientationChange float64  `json:"orientationChange"`
+	TurretChange      float64  `json:"turretChange"`
+	Shoot             bool     `json:"shoot"`
+	FirePower         float64  `json:"firePower"` // 0 shoots with power 1
+	Messages          []string `json:"messages"`  // radio messages for the teammates
 }
 
 type AI interface {
 	Compute(AIInput) AIOutput
-	Init()
+	// Init is called once before the match starts, rng is the only source of randomness a bot
+	// should use to keep matches reproducible
+	Init(rng *rand.Rand, arena ArenaInfo)
 	Name() string
 }
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// ArenaInfo describes the match a bot takes part in, it's handed to the bot once in Init
+type ArenaInfo struct {
+	ID          int                `json:"id"`   // ID of the bot's own tank
+	Team        string             `json:"team"` // empty in free-for-all matches
+	Width       float64            `json:"width"`
+	Height      float64            `json:"height"`
+	Obstacles   []vector.Rectangle `json:"obstacles"`
+	SpawnPoints []vector.Vec2      `json:"spawnPoints"`
+	Rules       ArenaRules         `json:"rules"`
+}
+
+// ArenaRules are the tuning values of a match that matter to a bot
+type ArenaRules struct {
+	MaxSpeed             float64 `json:"maxSpeed"`
+	MaxTurnPerTick       float64 `json:"maxTurnPerTick"`
+	MaxTurretTurnPerTick float64 `json:"maxTurretTurnPerTick"`
+	ViewRange            float64 `json:"viewRange"`
+	FieldOfView          float64 `json:"fieldOfView"`
+	CannonCooldown       int     `json:"cannonCooldown"`
+	ShellDamage          int     `json:"shellDamage"`
+	ShellSpeed           float64 `json:"shellSpeed"`
+	MinFirePower         float64 `json:"minFirePower"`
+	MaxFirePower         float64 `json:"maxFirePower"`
+	Health               int     `json:"health"`
+	Energy               float64 `json:"energy"`
+	EnergyRegen          float64 `json:"energyRegen"`
+	ShotEnergy           float64 `json:"shotEnergy"`
+	CollisionDamage      int     `json:"collisionDamage"`
+	RespawnWaitTime      int     `json:"respawnWaitTime"`
+	TankRadius           float64 `json:"tankRadius"`
+	FriendlyFire         bool    `json:"friendlyFire"`
+	MaxMessageBytes      int     `json:"maxMessageBytes"`
+}
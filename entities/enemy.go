@@ -1,9 +1,23 @@
 package entities
 
+import (
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// Enemy is what a bot knows about another tank. Enemies out of sight keep the values of the
+// last tick they were seen in, only Angle and Distance follow the observer.
 type Enemy struct {
-	Angle    float64
-	Distance float64
-	Health   int
-	Speed    int
-	State    State
+	ID       int         `json:"id"`
+	Name     string      `json:"name"`
+	Team     string      `json:"team"`  // teammates are listed as well
+	Angle    float64     `json:"angle"` // relative to the observer's orientation
+	Distance float64     `json:"distance"`
+	Position vector.Vec2 `json:"position"`
+	Velocity vector.Vec2 `json:"velocity"`
+	Heading  float64     `json:"heading"`
+	Health   int         `json:"health"`
+	Speed    float64     `json:"speed"`
+	State    State       `json:"state"`
+	Visible  bool        `json:"visible"`
+	LastSeen int64       `json:"lastSeen"` // tick the enemy was seen in the last time
 }
@@ -0,0 +1,25 @@
+package entities
+
+type EventType string
+
+const (
+	HitByShell    EventType = "hitByShell"    // Tank shot us for Damage
+	ShellHit      EventType = "shellHit"      // our shell hit Tank for Damage
+	ShellMissed   EventType = "shellMissed"   // our shell left the arena or hit Object
+	EnemyDied     EventType = "enemyDied"     // Tank died
+	Killed        EventType = "killed"        // we killed Tank
+	Respawned     EventType = "respawned"     // we're back in the match
+	WallCollision EventType = "wallCollision" // we hit a border on Side or Object
+	TankCollision EventType = "tankCollision" // we bumped into Tank
+	OutsideZone   EventType = "outsideZone"   // we took Damage outside of the safe zone
+)
+
+// Event is something that happened to a bot since its last Compute
+type Event struct {
+	Type   EventType `json:"type"`
+	Tick   int64     `json:"tick"`
+	Tank   int       `json:"tank"` // ID of the other tank involved, -1 if there is none
+	Damage int       `json:"damage,omitempty"`
+	Side   string    `json:"side,omitempty"`   // left, right, top or bottom border
+	Object string    `json:"object,omitempty"` // name of the map object
+}
@@ -0,0 +1,14 @@
+package entities
+
+import (
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// Flag is the state of a team's flag in capture the flag matches
+type Flag struct {
+	Team     string      `json:"team"`
+	Position vector.Vec2 `json:"position"`
+	Base     vector.Vec2 `json:"base"`
+	AtBase   bool        `json:"atBase"`
+	Carrier  int         `json:"carrier"` // ID of the tank carrying the flag, -1 if nobody has it
+}
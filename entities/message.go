@@ -0,0 +1,8 @@
+package entities
+
+// Message is a radio message from a teammate, sent in the tick before it's received
+type Message struct {
+	From    int    `json:"from"` // ID of the sending tank
+	Tick    int64  `json:"tick"`
+	Payload string `json:"payload"`
+}
@@ -2,7 +2,6 @@ package entities
 
 import (
 	"github.com/gentoomaniac/go-arena/vector"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type Object interface {
@@ -14,5 +13,4 @@ type Object interface {
 	SetSpeed(int)
 	Orientation() float64
 	SetOrientation(float64)
-	Sprite() *ebiten.Image
 }
@@ -1,9 +1,9 @@
 package entities
 
 import (
-	"github.com/gentoomaniac/go-arena/gfx"
+	"math"
+
 	"github.com/gentoomaniac/go-arena/vector"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type State int
@@ -11,26 +11,30 @@ type State int
 const (
 	Alive State = iota
 	Dead
+	Disqualified
+	Crashed
 )
 
 func (s State) String() string {
-	return [...]string{"Alive", "Dead"}[s]
+	return [...]string{"Alive", "Dead", "Disqualified", "Crashed"}[s]
 }
 
 type Player struct {
 	ID           int
 	Name         string
+	Team         string // players of a team win together, empty for free-for-all
 	State        State
 	Position     vector.Vec2
 	Acceleration float64
 	Friction     float64
 	Velocity     vector.Vec2
 	Orientation  vector.Vec2
+	Turret       float64 // turret angle in degrees relative to the heading
 	Mass         float64
 	Health       int
 	MaxHealth    int
-	Energy       int
-	MaxEnergy    int
+	Energy       float64
+	MaxEnergy    float64
 	//CurrentSpeed     float64
 	TargetSpeed      float64
 	MaxSpeed         float64
@@ -39,13 +43,17 @@ type Player struct {
 	CollidedWithTank bool
 	CannonCooldown   int
 	Hit              bool
-	Sprite           *ebiten.Image
-	Color            *gfx.Color
 	AI               AI
-	Animations       map[gfx.AnimationType]*gfx.Animation
+	Output           AIOutput // what the AI decided in the last tick
+	Events           []Event  // happened since the last Compute
 	NumberRespawns   int
 	MaxRespawns      int
 	RespawnCooldown  int
+	Kills            int
+	Deaths           int
+	TicksAlive       int64
+	Overruns         int   // number of ticks the AI didn't answer within the compute budget
+	CrashTick        int64 // tick in which the AI panicked
 }
 
 func (p *Player) UpdateSpeed(newSpeed float64) {
@@ -73,3 +81,13 @@ func (p *Player) UpdateOrientation(angle float64) {
 	p.Orientation = p.Velocity.Rotate(angle)
 	p.Velocity = p.Velocity.Rotate(angle)
 }
+
+// TurretOrientation is the absolute angle in degrees the cannon points to
+func (p *Player) TurretOrientation() float64 {
+	return p.Velocity.Angle() + p.Turret
+}
+
+// AddEnergy changes the energy without leaving the range of 0 to MaxEnergy
+func (p *Player) AddEnergy(energy float64) {
+	p.Energy = math.Max(0, math.Min(p.MaxEnergy, p.Energy+energy))
+}
@@ -0,0 +1,13 @@
+package entities
+
+import (
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// Projectile is a shell of another tank a bot can see
+type Projectile struct {
+	Position vector.Vec2 `json:"position"` // relative to the observer
+	Velocity vector.Vec2 `json:"velocity"`
+	Power    float64     `json:"power"`
+	Owner    int         `json:"owner"` // ID of the tank that shot it
+}
@@ -0,0 +1,14 @@
+package entities
+
+import (
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// SafeZone is the circle tanks have to stay in during shrinking safe zone matches
+type SafeZone struct {
+	Center       vector.Vec2 `json:"center"`
+	Radius       float64     `json:"radius"`
+	TargetRadius float64     `json:"targetRadius"` // radius once the zone stopped shrinking
+	Damage       int         `json:"damage"`       // health lost outside the zone every DamageTicks
+	DamageTicks  int64       `json:"damageTicks"`
+}
@@ -1,16 +1,11 @@
 package entities
 
 import (
-	"github.com/gentoomaniac/go-arena/gfx"
 	"github.com/gentoomaniac/go-arena/vector"
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
 func NewShell() *Shell {
-	s := &Shell{}
-	s.sprite = gfx.GetShellImage()
-
-	return s
+	return &Shell{}
 }
 
 type Shell struct {
@@ -19,15 +14,11 @@ type Shell struct {
 	Position        vector.Vec2
 	Movement        vector.Vec2
 	Orientation     float64
-	sprite          *ebiten.Image
 	Damage          int
+	Power           float64 // fire power the shell was shot with
 	Source          *Player
 }
 
 func (s Shell) Name() string {
 	return s.name
 }
-
-func (s Shell) Sprite() *ebiten.Image {
-	return s.sprite
-}
@@ -0,0 +1,12 @@
+package entities
+
+import (
+	"github.com/gentoomaniac/go-arena/vector"
+)
+
+// Zone is a control zone in king of the hill matches
+type Zone struct {
+	Name   string           `json:"name"`
+	Box    vector.Rectangle `json:"box"`
+	Owners []int            `json:"owners"` // IDs of the tanks holding the zone, empty if nobody or several sides are in it
+}
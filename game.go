@@ -4,12 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"math"
-	"math/rand"
-	"plugin"
 
 	_ "embed"
 
 	"github.com/gentoomaniac/ebitmx"
+	"github.com/gentoomaniac/go-arena/arena"
 	"github.com/gentoomaniac/go-arena/entities"
 	"github.com/gentoomaniac/go-arena/gfx"
 	"github.com/gentoomaniac/go-arena/physics"
@@ -21,40 +20,51 @@ import (
 )
 
 var (
-	ColisionDamage        = 0 // how much health does a player loose on colisions
-	CannonCooldown        = 60
-	ShellDamage           = 15
-	ViewRange             = 2500
-	MaxSpeed              = 25.0
-	MaxTurnPerTick        = 2.0
-	Acceleration          = 0.05
-	Friction              = Acceleration * 4
-	RespawnWaitTime       = 180 // number ticks
-	ShellSpeed            = 30.0
-	UpdateSpeed           = 1
-	StepMode              = true // update frame on key press only
-	NextTick              = false
-	TickCounter     int64 = 0
+	UpdateSpeed = 1
+	StepMode    = true // update frame on key press only
+	NextTick    = false
 )
 
 func NewGame() *Game {
 	return &Game{}
 }
 
+// simulation advances the drawn world by one tick
+type simulation interface {
+	Step()
+}
+
+const (
+	flagSize      = 120.0
+	flagBaseSize  = 300.0
+	safeZoneWidth = 40.0
+)
+
+var safeZoneColor = &gfx.Color{R: 1, G: .2, B: .2, Alpha: 1}
+
+// playerGfx holds everything needed to render a player
+type playerGfx struct {
+	sprite     *ebiten.Image
+	turret     *ebiten.Image
+	color      *gfx.Color
+	animations map[gfx.AnimationType]*gfx.Animation
+}
+
 type Game struct {
+	world          *arena.World
+	sim            simulation
+	recorder       *arena.Recorder
 	arenaMap       *ebitmx.TmxMap
 	scalingFactor  float64
 	screenBuffer   *ebiten.Image
-	players        []*entities.Player
-	shells         []*entities.Shell
+	playerGfx      []*playerGfx
+	teamColors     map[string]*gfx.Color
 	selectedPlayer *entities.Player
 	Pressed        map[ebiten.Key]bool
 	PressedBefore  map[ebiten.Key]bool
 	frameImage     *ebiten.Image
-	gameOver       bool
 	statsFrame     *ui.Stats
 	tabPressed     bool
-	respawns       int
 	Tick           int
 }
 
@@ -66,354 +76,102 @@ func (g *Game) Init() (err error) {
 	if err != nil {
 		return
 	}
-	g.gameOver = false
-	g.statsFrame = ui.NewStats("Stats", g.players)
-	return
-}
-
-func (g *Game) WithMap(tmxMap *ebitmx.TmxMap) *Game {
-	g.arenaMap = tmxMap
-	return g
-}
 
-func (g *Game) WithScalingFactor(s float64) *Game {
-	g.scalingFactor = s
-	return g
-}
-
-//go:embed gfx/fire_transparent.gif
-var fireGif []byte
-
-func (g *Game) WithRespawns(respawns int) *Game {
-	g.respawns = respawns
-	return g
-}
-
-func removeSpawn(s []*ebitmx.Object, i int) []*ebitmx.Object {
-	if i >= len(s) || i < 0 {
-		return s
+	// every team and every tank without a team gets its own color
+	sides := make(map[string]bool)
+	for _, p := range g.world.Players {
+		sides[arena.Side(p)] = true
 	}
-
-	s[i] = s[len(s)-1]
-	return s[:len(s)-1]
-}
-
-func (g *Game) WithBots(bots []string) *Game {
-	var color *gfx.Color
-	spawnPoints := g.arenaMap.GetObjectGroupByName("spawn_points").Objects
-	for index, botModulePath := range bots {
-		botPlugin, err := plugin.Open(botModulePath)
-		if err != nil {
-			log.Error().Err(err).Msg("failed loading bot")
-			return nil
-		}
-		botObj, err := botPlugin.Lookup("Bot")
-		if err != nil {
-			log.Error().Err(err).Msg("no object called 'Bot' found")
-			return nil
-		}
-		ai, ok := botObj.(entities.AI)
-		if !ok {
-			log.Error().Err(err).Msg("bot object doesn't implement the AI interface")
-			return nil
-		}
-		ai.Init()
-
+	palette := gfx.Palette(len(sides))
+	g.teamColors = make(map[string]*gfx.Color)
+	for _, p := range g.world.Players {
 		playerSprite, err := gfx.GetPlayerSprite()
 		if err != nil {
-			return nil
+			return err
 		}
-
-		switch index % 4 {
-		case 0:
-			color = &gfx.Color{R: 1, G: .7, B: .7, Alpha: 1}
-		case 1:
-			color = &gfx.Color{R: 1, G: 1, B: .7, Alpha: 1}
-		case 2:
-			color = &gfx.Color{R: .7, G: 1, B: .7, Alpha: 1}
-		case 3:
-			color = &gfx.Color{R: .7, G: .7, B: 1, Alpha: 1}
+		turretSprite, err := gfx.GetTurretSprite()
+		if err != nil {
+			return err
 		}
 
-		spawnIndex := rand.Int() % len(spawnPoints)
-		spawnPoint := spawnPoints[spawnIndex]
-		spawnPoints = removeSpawn(spawnPoints, spawnIndex)
-
-		player := &entities.Player{
-			ID:              index,
-			Name:            ai.Name(),
-			State:           entities.Alive,
-			Position:        vector.Vec2{X: float64(spawnPoint.X), Y: float64(spawnPoint.Y)},
-			Velocity:        vector.Vec2{},
-			Mass:            10,
-			Health:          100,
-			MaxHealth:       100,
-			Energy:          100,
-			MaxEnergy:       100,
-			MaxSpeed:        MaxSpeed,
-			Acceleration:    Acceleration,
-			Friction:        Friction,
-			Sprite:          playerSprite,
-			Color:           color,
-			CollisionRadius: (float64(playerSprite.Bounds().Dx()) / 2) * .6,
-			Collided:        false,
-			AI:              ai,
-			MaxRespawns:     g.respawns,
+		playerColor, ok := g.teamColors[arena.Side(p)]
+		if !ok {
+			playerColor = palette[len(g.teamColors)]
+			g.teamColors[arena.Side(p)] = playerColor
 		}
-		player.Animations = make(map[gfx.AnimationType]*gfx.Animation)
 
 		fireAnimation, err := gfx.AnimationFromGIF(bytes.NewReader(fireGif))
 		if err != nil {
 			log.Error().Err(err).Msg("could not load fire animation")
-			return nil
+			return err
 		}
 		fireAnimation.AnimationSpeed = 5
-		player.Animations[gfx.Fire] = fireAnimation
-
-		g.players = append(g.players, player)
-	}
-	return g
-}
-
-func (g *Game) updatePlayer(p *entities.Player) {
-	enemies := make([]*entities.Enemy, 0)
-	for _, e := range g.players {
-		if e != p {
-			distance := physics.Distance(p.Position, e.Position)
-
-			// add visible enemies to input data
-			if distance <= float64(ViewRange) {
-				angle := (math.Atan2(e.Position.Y-p.Position.Y, e.Position.X-p.Position.X) * 180 / math.Pi) - p.Velocity.Angle()
-				enemies = append(enemies, &entities.Enemy{
-					Distance: distance,
-					Angle:    angle,
-					State:    e.State,
-				})
-			}
-		}
-	}
 
-	if p.State == entities.Alive {
-		output := p.AI.Compute(entities.AIInput{
-			Position:         p.Position,
-			TargetSpeed:      p.TargetSpeed,
-			MaxSpeed:         p.MaxSpeed,
-			CurrentSpeed:     p.Velocity.Length(),
-			Orientation:      p.Velocity.Angle(),
-			Collided:         p.Collided,
-			CollidedWithTank: p.CollidedWithTank,
-			Hit:              p.Hit,
-			CannonReady:      p.CannonCooldown <= 0,
-			Enemy:            enemies,
+		g.playerGfx = append(g.playerGfx, &playerGfx{
+			sprite:     playerSprite,
+			turret:     turretSprite,
+			color:      playerColor,
+			animations: map[gfx.AnimationType]*gfx.Animation{gfx.Fire: fireAnimation},
 		})
+	}
 
-		p.UpdateSpeed(output.Speed)
-
-		if output.OrientationChange > 0 {
-			if math.Abs(output.OrientationChange) <= MaxTurnPerTick {
-				p.UpdateOrientation(output.OrientationChange)
-			} else {
-				p.UpdateOrientation(MaxTurnPerTick * (output.OrientationChange / math.Abs(output.OrientationChange)))
-			}
-		}
+	g.statsFrame = ui.NewStats("Stats", g.world.Players).WithScore(g.world.ScoreOf).WithStatus(g.status)
+	return
+}
 
-		if p.CannonCooldown > 0 {
-			p.CannonCooldown--
-		} else {
-			if output.Shoot {
-				p.CannonCooldown = CannonCooldown
-				newShell := entities.NewShell()
-				newShell.Source = p
-				newShell.Movement = vector.Vec2{X: 1, Y: 0}.Rotate(p.Velocity.Angle()).WithLength(ShellSpeed)
-				newShell.Orientation = p.Velocity.Angle()
-				newShell.Position = p.Position //.Sum(vector.Vec2{p.CollisionRadius, 0}.Rotate(p.Orientation))
-				newShell.Damage = ShellDamage
-				newShell.CollisionRadius = float64(newShell.Sprite().Bounds().Dx()) / 2
-
-				g.shells = append(g.shells, newShell)
-			}
+// status describes how the match ended or that it's in sudden death
+func (g *Game) status() string {
+	switch {
+	case g.world.GameOver:
+		winners := g.world.Winners()
+		if len(winners) == 0 {
+			return "draw"
 		}
-	} else if p.State == entities.Dead {
-		p.UpdateSpeed(0)
-		if p.NumberRespawns < p.MaxRespawns {
-			if p.RespawnCooldown > 0 {
-				p.RespawnCooldown--
-			} else {
-				p.TargetSpeed = 0
-				p.Velocity = vector.Vec2{}
-				p.Orientation = vector.Vec2{X: rand.Float64(), Y: rand.Float64()}
-				spawnPoints := g.arenaMap.GetObjectGroupByName("spawn_points").Objects
-				spawnPoint := spawnPoints[rand.Int()%len(spawnPoints)]
-				p.Position.X = float64(spawnPoint.X)
-				p.Position.Y = float64(spawnPoint.Y)
-				p.State = entities.Alive
-				p.Health = p.MaxHealth
-				p.NumberRespawns++
-			}
+		name := winners[0].Team
+		if name == "" {
+			name = winners[0].Name
 		}
-	}
-
-	// Collisions
-	for index, e := range g.players {
-		if e != p {
-			circleDistance := physics.DistanceBetweenCircles(vector.Circle{e.Position, e.CollisionRadius}, vector.Circle{p.Position, p.CollisionRadius})
-
-			// ToDo: needs refactor to break this code into physics module and write tests for it
-			// collisions: https://www.youtube.com/watch?v=LPzyNOHY3A4&ab_channel=javidx9
-			if circleDistance < 0 {
-				// check for static collision
-				displaceBy := math.Abs(circleDistance) / 2
-				vPlayerEnemy := p.Position.ToPoint(e.Position)
-
-				// ToDo: Multiple collisions happen right after one another which causes hughe spikes in ImpactVelocity
-				// ToDo: This can move a tank out of the level boundaries
-				vDisplace := vPlayerEnemy.Unit().ScalarProduct(displaceBy)
-				p.Position.X += vDisplace.X
-				p.Position.Y += vDisplace.Y
-				g.players[index].Position.X -= vDisplace.X
-				g.players[index].Position.Y -= vDisplace.Y
-
-				p.CollidedWithTank = true
-				g.players[index].CollidedWithTank = true
-
-				// vector between center points
-				vecPE := p.Position.ToPoint(e.Position)
-
-				// normal vector between balls
-				normal := vecPE.Unit()
-
-				// perpendicular vector
-				tangent := normal.Perpendicular()
-
-				// Dot Product Tangent
-				dpTanP := p.Velocity.DotProduct(tangent)
-				dpTanE := e.Velocity.DotProduct(tangent)
-
-				// Dot Product Normal
-				dpNormP := p.Velocity.DotProduct(normal)
-				dpNormE := e.Velocity.DotProduct(normal)
-
-				// Conservation of momentum in D
-				mP := (dpNormP*(p.Mass-e.Mass) + 2.0*e.Mass*dpNormE) / (p.Mass + e.Mass)
-				mE := (dpNormE*(e.Mass-p.Mass) + 2.0*p.Mass*dpNormP) / (p.Mass + e.Mass)
-
-				// Update impact velocity // Switched +/-
-				p.Velocity.X -= (tangent.X*dpTanP + normal.X*mP) * g.scalingFactor // ToDo: Tweak this magic number a bit more
-				p.Velocity.Y -= (tangent.Y*dpTanP + normal.Y*mP) * g.scalingFactor
-				g.players[index].Velocity.X += (tangent.X*dpTanE + normal.X*mE) * g.scalingFactor
-				g.players[index].Velocity.Y += (tangent.Y*dpTanE + normal.Y*mE) * g.scalingFactor
-
-			}
+		if g.world.Reason == "" {
+			return fmt.Sprintf("%s won", name)
 		}
+		return fmt.Sprintf("%s won (%s)", name, g.world.Reason)
+	case g.world.SuddenDeath:
+		return "sudden death"
 	}
+	return ""
+}
 
-	collisionPoint := vector.Vec2{X: p.Position.X + p.Velocity.X, Y: p.Position.Y + p.Velocity.Y}
-	p.Collided = false
-	// check left border
-	if collisionPoint.X-p.CollisionRadius < 0.0 || physics.PointLineDistance(vector.Vec2{0, 0}, vector.Vec2{0, float64(g.arenaMap.PixelHeight)}, collisionPoint) <= p.CollisionRadius {
-		p.Collided = true
-		p.Health -= ColisionDamage
-		p.Position.X = p.CollisionRadius + 1
-		p.Velocity.X = 0
-		log.Debug().Int64("tick", TickCounter).Str("name", p.Name).Str("new", p.Position.String()).Msg("collided left")
-	}
-	// check right border
-	if collisionPoint.X+p.CollisionRadius > float64(g.arenaMap.PixelWidth) ||
-		physics.PointLineDistance(vector.Vec2{float64(g.arenaMap.PixelWidth), 0}, vector.Vec2{float64(g.arenaMap.PixelWidth), float64(g.arenaMap.PixelHeight)}, collisionPoint) <= p.CollisionRadius {
-		p.Collided = true
-		p.Health -= ColisionDamage
-		p.Position.X = float64(g.arenaMap.PixelWidth) - p.CollisionRadius - 1
-		p.Velocity.X = 0
-		log.Debug().Int64("tick", TickCounter).Str("name", p.Name).Str("new", p.Position.String()).Msg("collided right")
-		//StepMode = true
-	}
-	// check top border
-	if collisionPoint.Y-p.CollisionRadius < 0.0 ||
-		physics.PointLineDistance(vector.Vec2{0, 0}, vector.Vec2{float64(g.arenaMap.PixelWidth), 0}, collisionPoint) <= p.CollisionRadius {
-		p.Collided = true
-		p.Health -= ColisionDamage
-		p.Position.Y = p.CollisionRadius + 1
-		p.Velocity.Y = 0
-		log.Debug().Int64("tick", TickCounter).Str("name", p.Name).Str("new", p.Position.String()).Msg("collided top")
-	}
-	// check bottom border
-	if collisionPoint.Y+p.CollisionRadius > float64(g.arenaMap.PixelHeight) ||
-		physics.PointLineDistance(vector.Vec2{0, float64(g.arenaMap.PixelHeight)}, vector.Vec2{float64(g.arenaMap.PixelWidth), float64(g.arenaMap.PixelHeight)}, collisionPoint) <= p.CollisionRadius {
-		p.Collided = true
-		p.Health -= ColisionDamage
-		p.Position.Y = float64(g.arenaMap.PixelHeight) - p.CollisionRadius - 1
-		p.Velocity.Y = 0
-		log.Debug().Int64("tick", TickCounter).Str("name", p.Name).Str("new", p.Position.String()).Msg("collided bottom")
-	}
-
-	if p.Collided {
-		if p.Health <= 0 && p.State == entities.Alive {
-			p.RespawnCooldown = RespawnWaitTime
-			p.State = entities.Dead
-			log.Info().Str("name", p.Name).Msg("crashed into level boundary")
-		}
-	}
+func (g *Game) WithMap(tmxMap *ebitmx.TmxMap) *Game {
+	g.arenaMap = tmxMap
+	return g
+}
 
-	// check hit by shell
-	p.Hit = false
-	for i, shell := range g.shells {
-		if shell.Source != p {
-			if distance := physics.DistanceBetweenCircles(
-				vector.Circle{shell.Position, shell.Source.CollisionRadius},
-				vector.Circle{p.Position, p.CollisionRadius}); distance < 0 {
-
-				// ToDo: This makes the shell disappear before it visually hit
-				// the shell should get a hit flag and get removed after the next draw
-				g.shells = remove(g.shells, i)
-				p.Hit = true
-				p.Health -= shell.Damage
-				//ToDo: shell impact causes velocity change
-				if p.Health <= 0 && p.State == entities.Alive {
-					p.RespawnCooldown = RespawnWaitTime
-					p.State = entities.Dead
-					log.Info().Str("target", p.Name).Str("source", shell.Source.Name).Int("max", p.MaxRespawns).Int("spawns", p.NumberRespawns).Msg("killed")
-				}
-			}
-		}
-	}
+func (g *Game) WithWorld(world *arena.World) *Game {
+	g.world = world
+	g.sim = world
+	return g
+}
 
-	//ToDo: Object collisions are currently not working
-	// mapObjects := g.arenaMap.GetObjectGroupByName("collisionmap").Objects
-	// pObject := vector.Rect(
-	// 	p.CollisionBox().Min.X+p.Movement.X,
-	// 	p.CollisionBox().Min.Y+p.Movement.Y,
-	// 	p.CollisionBox().Max.X+p.Movement.X,
-	// 	p.CollisionBox().Max.Y+p.Movement.Y,
-	// )
-	// p.Collided = false
-	// for _, object := range mapObjects {
-	// 	objectBox := vector.Rect(float64(object.X), float64(object.Y), float64(object.X+object.Width), float64(object.Y+object.Height))
-	// 	if checkColisionBox(pObject, objectBox) || checkColisionBox(objectBox, pObject) {
-	// 		p.Collided = true
-	// 		p.Health -= ColisionDamage
-	// 		if p.Health <= 0 {
-	// 			p.RespawnCooldown = RespawnWaitTime
-	// 			p.State = entities.Dead
-	// 			log.Info().Str("name", p.Name).Str("object", object.Name).Int("max", p.MaxRespawns).Int("spawns", p.NumberRespawns).Msgf("crashed into object")
-	// 		}
-	// 		p.CurrentSpeed = 0.0
-	// 		p.Movement.X = 0
-	// 		p.Movement.Y = 0
-	// 	}
-	// }
-
-	TickCounter += 1
+// WithPlayback draws a recorded match instead of simulating one
+func (g *Game) WithPlayback(pb *arena.Playback) *Game {
+	g.world = pb.World
+	g.sim = pb
+	return g
 }
 
-func remove(s []*entities.Shell, i int) []*entities.Shell {
-	if i >= len(s) || i < 0 {
-		return s
-	}
+func (g *Game) WithRecorder(r *arena.Recorder) *Game {
+	g.recorder = r
+	return g
+}
 
-	s[i] = s[len(s)-1]
-	return s[:len(s)-1]
+func (g *Game) WithScalingFactor(s float64) *Game {
+	g.scalingFactor = s
+	return g
 }
 
+//go:embed gfx/fire_transparent.gif
+var fireGif []byte
+
 func (g *Game) handleInput() {
 	g.Pressed = map[ebiten.Key]bool{}
 	g.tabPressed = false
@@ -423,13 +181,13 @@ func (g *Game) handleInput() {
 			g.Pressed[k] = true
 			switch k {
 			case ebiten.Key1:
-				g.selectedPlayer = g.players[0]
+				g.selectedPlayer = g.world.Players[0]
 			case ebiten.Key2:
-				g.selectedPlayer = g.players[1]
+				g.selectedPlayer = g.world.Players[1]
 			case ebiten.Key3:
-				g.selectedPlayer = g.players[2]
+				g.selectedPlayer = g.world.Players[2]
 			case ebiten.Key4:
-				g.selectedPlayer = g.players[3]
+				g.selectedPlayer = g.world.Players[3]
 			case ebiten.KeyEscape:
 				g.selectedPlayer = nil
 			case ebiten.KeyTab:
@@ -459,7 +217,7 @@ func (g *Game) handleInput() {
 	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		mx, my := ebiten.CursorPosition()
 		pointer := vector.Vec2{X: float64(mx) / g.scalingFactor, Y: float64(my) / g.scalingFactor}
-		for _, p := range g.players {
+		for _, p := range g.world.Players {
 			if physics.DistanceBetweenCircles(vector.Circle{pointer, 1}, vector.Circle{p.Position, p.CollisionRadius}) < 0 {
 				g.selectedPlayer = p
 				break
@@ -468,37 +226,6 @@ func (g *Game) handleInput() {
 	}
 }
 
-func (g *Game) updateShells() {
-	// calculate shells
-	for i, s := range g.shells {
-		collisionPoint := vector.Vec2{X: s.Position.X + s.Movement.X, Y: s.Position.Y + s.Movement.Y}
-		if collisionPoint.X < 0 || collisionPoint.X > float64(g.arenaMap.PixelWidth) {
-			g.shells = remove(g.shells, i)
-			continue
-		} else {
-			s.Position.X += s.Movement.X
-		}
-		if collisionPoint.Y < 0 || collisionPoint.Y > float64(g.arenaMap.PixelHeight) {
-			g.shells = remove(g.shells, i)
-			continue
-		} else {
-			s.Position.Y += s.Movement.Y
-		}
-	}
-}
-
-func (g *Game) isGameOver() {
-	var alivePlayers = 0
-	for _, p := range g.players {
-		if p.State == entities.Alive || p.NumberRespawns < p.MaxRespawns {
-			alivePlayers++
-		}
-	}
-	if alivePlayers <= 1 {
-		g.gameOver = true
-	}
-}
-
 func (g *Game) Update() error {
 	g.handleInput()
 
@@ -507,22 +234,11 @@ func (g *Game) Update() error {
 	}
 
 	if g.Tick%UpdateSpeed == 0 || UpdateSpeed <= 0 {
-		if !g.gameOver {
-			// update all player positions
-			for _, p := range g.players {
-				p.Position.X += p.Velocity.X
-				p.Position.Y += p.Velocity.Y
-			}
-
-			for _, p := range g.players {
-				g.updatePlayer(p)
-			}
-
-			g.updateShells()
+		g.sim.Step()
+		if g.recorder != nil {
+			g.recorder.Capture()
 		}
 
-		g.isGameOver()
-
 		g.Tick = 1
 	} else {
 		g.Tick++
@@ -540,19 +256,57 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	// collisionOp.ColorM.Scale(1, 0, 0, .75)
 	// g.screenBuffer.DrawImage(g.arenaMap.GetObjectGroupByName("collisionmap").DebugRender(g.arenaMap, g.scalingFactor), collisionOp)
 
+	// ======== Draw Flags =========
+	if ctf, ok := g.world.Mode.(*arena.CaptureTheFlag); ok {
+		for _, f := range ctf.Flags() {
+			flagColor, ok := g.teamColors[f.Team]
+			if !ok {
+				flagColor = &gfx.Color{R: .5, G: .5, B: .5, Alpha: 1}
+			}
+			ebitenutil.DrawRect(g.screenBuffer, f.Base.X-flagBaseSize/2, f.Base.Y-flagBaseSize/2, flagBaseSize, flagBaseSize, flagColor.ToRGBA(.3))
+			ebitenutil.DrawRect(g.screenBuffer, f.Position.X-flagSize/2, f.Position.Y-flagSize/2, flagSize, flagSize, flagColor.ToRGBA(1))
+		}
+	}
+
+	// ======== Draw Zones =========
+	if koth, ok := g.world.Mode.(*arena.KingOfTheHill); ok {
+		for _, zone := range koth.Zones() {
+			zoneColor := &gfx.Color{R: .5, G: .5, B: .5, Alpha: 1}
+			if len(zone.Owners) > 0 {
+				zoneColor = g.playerGfx[zone.Owners[0]].color
+			}
+			ebitenutil.DrawRect(g.screenBuffer, zone.Box.Min.X, zone.Box.Min.Y, zone.Box.Max.X-zone.Box.Min.X, zone.Box.Max.Y-zone.Box.Min.Y, zoneColor.ToRGBA(.3))
+		}
+	}
+
+	// ======== Draw Safe Zone =========
+	if safeZone, ok := g.world.Mode.(*arena.SafeZone); ok {
+		zone := safeZone.Zone(g.world)
+		gfx.DrawCircle(g.screenBuffer, zone.Center.X, zone.Center.Y, zone.TargetRadius, safeZoneWidth/2, safeZoneColor.ToRGBA(.3))
+		gfx.DrawCircle(g.screenBuffer, zone.Center.X, zone.Center.Y, zone.Radius, safeZoneWidth, safeZoneColor.ToRGBA(1))
+	}
+
 	// ======== Draw Player =========
-	for _, p := range g.players {
+	for i, p := range g.world.Players {
+		pg := g.playerGfx[i]
 		playerOp := ebiten.DrawImageOptions{}
-		playerOp = gfx.Rotate(p.Sprite, playerOp, int(p.Orientation.Angle()))
-		playerOp.ColorM.Scale(p.Color.R, p.Color.G, p.Color.B, p.Color.Alpha)
-		playerOp.GeoM.Translate(p.Position.X-float64(p.Sprite.Bounds().Dx()/2), p.Position.Y-float64(p.Sprite.Bounds().Dy()/2))
+		playerOp = gfx.Rotate(pg.sprite, playerOp, int(p.Orientation.Angle()))
+		playerOp.ColorM.Scale(pg.color.R, pg.color.G, pg.color.B, pg.color.Alpha)
+		playerOp.GeoM.Translate(p.Position.X-float64(pg.sprite.Bounds().Dx()/2), p.Position.Y-float64(pg.sprite.Bounds().Dy()/2))
+
+		g.screenBuffer.DrawImage(pg.sprite, &playerOp)
 
-		g.screenBuffer.DrawImage(p.Sprite, &playerOp)
+		turretOp := ebiten.DrawImageOptions{}
+		turretOp = gfx.Rotate(pg.turret, turretOp, int(p.TurretOrientation()))
+		turretOp.ColorM.Scale(pg.color.R, pg.color.G, pg.color.B, pg.color.Alpha)
+		turretOp.GeoM.Translate(p.Position.X-float64(pg.turret.Bounds().Dx()/2), p.Position.Y-float64(pg.turret.Bounds().Dy()/2))
+		g.screenBuffer.DrawImage(pg.turret, &turretOp)
 
-		if p.State == entities.Dead {
+		if p.State != entities.Alive {
+			fire := pg.animations[gfx.Fire]
 			fireOp := ebiten.DrawImageOptions{}
-			fireOp.GeoM.Translate(p.Position.X-float64(p.Animations[gfx.Fire].Width/2), p.Position.Y-float64(p.Animations[gfx.Fire].Height/2))
-			g.screenBuffer.DrawImage(p.Animations[gfx.Fire].GetFrame(), &fireOp)
+			fireOp.GeoM.Translate(p.Position.X-float64(fire.Width/2), p.Position.Y-float64(fire.Height/2))
+			g.screenBuffer.DrawImage(fire.GetFrame(), &fireOp)
 		}
 
 		if p == g.selectedPlayer {
@@ -564,14 +318,16 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	}
 
 	// ======== Draw Shells =========
-	for _, s := range g.shells {
+	shellSprite := gfx.GetShellImage()
+	for _, s := range g.world.Shells {
 		shellOp := ebiten.DrawImageOptions{}
-		shellOp = gfx.Rotate(s.Sprite(), shellOp, int(s.Orientation))
+		shellOp = gfx.Scale(shellSprite, shellOp, math.Sqrt(s.Power))
+		shellOp = gfx.Rotate(shellSprite, shellOp, int(s.Orientation))
 
 		// to move the image
-		shellOp.GeoM.Translate(s.Position.X-float64(s.Sprite().Bounds().Dx()/2), s.Position.Y-float64(s.Sprite().Bounds().Dy()/2))
+		shellOp.GeoM.Translate(s.Position.X-float64(shellSprite.Bounds().Dx()/2), s.Position.Y-float64(shellSprite.Bounds().Dy()/2))
 
-		g.screenBuffer.DrawImage(s.Sprite(), &shellOp)
+		g.screenBuffer.DrawImage(shellSprite, &shellOp)
 		//ebitenutil.DrawRect(g.screenBuffer, s.Position.X-s.CollisionRadius, s.Position.Y-s.CollisionRadius, s.CollisionRadius*2, s.CollisionRadius*2, color.Gray{})
 	}
 
@@ -581,7 +337,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	scaledScreenOp.GeoM.Scale(g.scalingFactor, g.scalingFactor)
 	screen.DrawImage(g.screenBuffer, scaledScreenOp)
 
-	if g.gameOver || g.tabPressed {
+	if g.world.GameOver || g.tabPressed {
 		frame := g.statsFrame.Image(true)
 		op := &ebiten.DrawImageOptions{}
 		op.GeoM.Scale(0.5, 0.5)
@@ -595,12 +351,13 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	if g.selectedPlayer != nil {
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Selected Player: %s", g.selectedPlayer.Name), 16, 64)
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Health: %d/%d", g.selectedPlayer.Health, g.selectedPlayer.MaxHealth), 16, 80)
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Position: %s", g.selectedPlayer.Position), 16, 96)
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Speed: %f", g.selectedPlayer.Velocity.Length()), 16, 112)
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Velocity: %s", g.selectedPlayer.Velocity), 16, 128)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Energy: %.0f/%.0f", g.selectedPlayer.Energy, g.selectedPlayer.MaxEnergy), 16, 96)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Position: %s", g.selectedPlayer.Position), 16, 112)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Speed: %f", g.selectedPlayer.Velocity.Length()), 16, 128)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Velocity: %s", g.selectedPlayer.Velocity), 16, 144)
 	} else {
-		for i, p := range g.players {
-			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("#%d - %s H(%d/%d) S(%.0f/%.0f) %s", i+1, p.Name, p.Health, p.MaxHealth, math.Round(p.Velocity.Length()), p.MaxSpeed, p.Position), 16, 48+i*16)
+		for i, p := range g.world.Players {
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("#%d - %s H(%d/%d) E(%.0f/%.0f) S(%.0f/%.0f) %s", i+1, p.Name, p.Health, p.MaxHealth, p.Energy, p.MaxEnergy, math.Round(p.Velocity.Length()), p.MaxSpeed, p.Position), 16, 48+i*16)
 		}
 	}
 }
@@ -1,6 +1,10 @@
 package gfx
 
-import "fmt"
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
 
 type Color struct {
 	R     float64
@@ -12,3 +16,28 @@ type Color struct {
 func (c Color) String() string {
 	return fmt.Sprintf("(%f, %f, %f, %f)", c.R, c.G, c.B, c.Alpha)
 }
+
+// ToRGBA converts the color for drawing primitives, alpha scales the opacity on top of Alpha
+func (c Color) ToRGBA(alpha float64) color.RGBA {
+	a := c.Alpha * alpha
+	return color.RGBA{R: uint8(c.R * a * 255), G: uint8(c.G * a * 255), B: uint8(c.B * a * 255), A: uint8(a * 255)}
+}
+
+// Palette returns n colors with evenly spread hues, light enough to tint the sprites with
+func Palette(n int) []*Color {
+	colors := make([]*Color, n)
+	for i := range colors {
+		r, g, b := hueToRGB(float64(i) / float64(n))
+		colors[i] = &Color{R: .6 + .4*r, G: .6 + .4*g, B: .6 + .4*b, Alpha: 1}
+	}
+	return colors
+}
+
+// hueToRGB converts a hue in [0, 1) to a fully saturated color
+func hueToRGB(hue float64) (r, g, b float64) {
+	channel := func(n float64) float64 {
+		k := math.Mod(n+hue*6, 6)
+		return 1 - math.Max(0, math.Min(k, math.Min(4-k, 1)))
+	}
+	return channel(5), channel(3), channel(1)
+}
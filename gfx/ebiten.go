@@ -1,9 +1,11 @@
 package gfx
 
 import (
+	"image/color"
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 func Rotate(img *ebiten.Image, op ebiten.DrawImageOptions, degrees int) ebiten.DrawImageOptions {
@@ -13,3 +15,21 @@ func Rotate(img *ebiten.Image, op ebiten.DrawImageOptions, degrees int) ebiten.D
 
 	return op
 }
+
+// Scale resizes the image around its center
+func Scale(img *ebiten.Image, op ebiten.DrawImageOptions, factor float64) ebiten.DrawImageOptions {
+	op.GeoM.Translate(-float64(img.Bounds().Dx())/2, -float64(img.Bounds().Dy())/2)
+	op.GeoM.Scale(factor, factor)
+	op.GeoM.Translate(float64(img.Bounds().Dx())/2, float64(img.Bounds().Dy())/2)
+
+	return op
+}
+
+// DrawCircle draws the outline of a circle as a chain of squares with the given line width
+func DrawCircle(dst *ebiten.Image, x, y, radius, width float64, clr color.Color) {
+	steps := int(math.Ceil(2 * math.Pi * radius / width))
+	for i := 0; i < steps; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(steps)
+		ebitenutil.DrawRect(dst, x+radius*math.Cos(angle)-width/2, y+radius*math.Sin(angle)-width/2, width, width, clr)
+	}
+}
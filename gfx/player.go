@@ -14,11 +14,25 @@ var (
 	tankScalingFactor = 4.0
 )
 
-//go:embed tank.png
-var tankImage []byte
+//go:embed hull.png
+var hullImage []byte
 
+//go:embed turret.png
+var turretImage []byte
+
+// GetPlayerSprite returns the tank hull, the turret is drawn separately on top of it
 func GetPlayerSprite() (*ebiten.Image, error) {
-	img, err := png.Decode(bytes.NewReader(tankImage))
+	return tankSprite(hullImage)
+}
+
+// GetTurretSprite returns the turret, it has the same size as the hull and rotates around its center
+func GetTurretSprite() (*ebiten.Image, error) {
+	return tankSprite(turretImage)
+}
+
+// tankSprite scales the image up and turns it so 0 degrees points right
+func tankSprite(raw []byte) (*ebiten.Image, error) {
+	img, err := png.Decode(bytes.NewReader(raw))
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"runtime/pprof"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/gentoomaniac/logging"
@@ -16,8 +17,44 @@ var (
 var cli struct {
 	logging.LoggingConfig
 
-	Bot      []string `short:"b" help:"add another bot with this filename to the arena" required:""`
-	Respawns int      `short:"r" help:"Number of respawns"`
+	Config string `type:"existingfile" help:"JSON file with the rules of the match, unset values keep their default (see rules/default.json)"`
+
+	Run struct {
+		Bot      []string `short:"b" help:"add another bot with this filename to the arena, either a go plugin (.so) or an executable. Prefix it with a team name like team1:bot.so for team matches" required:""`
+		Map      string   `short:"m" default:"maps/test.tmx" type:"existingfile" help:"Tiled map (.tmx) to fight in"`
+		Respawns int      `short:"r" help:"Number of respawns"`
+		Seed     int64    `help:"Seed for the match, a random one is picked if not set"`
+		Record   string   `help:"write a replay of the match to this file"`
+
+		Mode       string `enum:"lms,dm,timed,ctf,koth,zone" default:"lms" help:"Last man standing (lms), deathmatch (dm), most points in time (timed), capture the flag (ctf), king of the hill (koth) or last man standing in a shrinking safe zone (zone)"`
+		ScoreLimit int    `help:"Score needed to win in scoring modes, 0 uses the mode's default"`
+		TickLimit  int64  `help:"Length of timed and king of the hill matches or the time the safe zone shrinks in ticks, 0 uses the mode's default. It can't be longer than --max-ticks"`
+
+		MaxTicks    int64 `default:"36000" help:"End the match after this many ticks, the side with the most health and then kills wins. 0 disables the limit"`
+		SuddenDeath int64 `help:"Go into sudden death for this many ticks at the tick limit instead: health is halved and nobody respawns"`
+
+		ComputeBudget time.Duration `default:"1s" help:"Time a bot may take per tick before it gets a no-op move, 0 disables the limit"`
+		MaxOverruns   int           `default:"10" help:"Disqualify bots after exceeding the compute budget this many times, 0 never disqualifies"`
+	} `cmd:"" default:"1" help:"Start a match"`
+
+	Replay struct {
+		File string `arg:"" type:"existingfile" help:"replay file to play back"`
+	} `cmd:"" help:"Play back a recorded match"`
+
+	Tournament struct {
+		Bot      []string `arg:"" type:"existingfile" help:"bots taking part in the tournament"`
+		Map      string   `short:"m" default:"maps/test.tmx" type:"existingfile" help:"Tiled map (.tmx) to fight in"`
+		Rounds   int      `short:"n" default:"10" help:"Number of rounds, every round plays each match once"`
+		Format   string   `enum:"pairs,ffa,all" default:"all" help:"Play every pairing (pairs), one free-for-all (ffa) or both (all)"`
+		Respawns int      `short:"r" help:"Number of respawns"`
+		Seed     int64    `help:"Seed for the tournament, a random one is picked if not set"`
+		MaxTicks int64    `default:"36000" help:"End matches after this many ticks, the bot with the most health and then kills wins. 0 disables the limit"`
+
+		SuddenDeath int64 `help:"Go into sudden death for this many ticks at the tick limit instead: health is halved and nobody respawns"`
+
+		ComputeBudget time.Duration `default:"1s" help:"Time a bot may take per tick before it gets a no-op move, 0 disables the limit"`
+		MaxOverruns   int           `default:"10" help:"Disqualify bots after exceeding the compute budget this many times, 0 never disqualifies"`
+	} `cmd:"" help:"Run a headless round-robin tournament and print the standings"`
 
 	ProfileMemory string `help:"write a memory profile"`
 	ProfileCPU    string `help:"write a cpu profile"`
@@ -47,7 +84,14 @@ func main() {
 		defer pprof.StopCPUProfile()
 	}
 
-	run(cli.Bot)
+	switch ctx.Command() {
+	case "run":
+		run(cli.Run.Bot)
+	case "replay <file>":
+		replay(cli.Replay.File)
+	case "tournament <bot>":
+		tournament(cli.Tournament.Bot)
+	}
 
 	if cli.ProfileMemory != "" {
 		f, err := os.Create(cli.ProfileMemory)
@@ -55,3 +55,40 @@ func DoMovingCirclesCollide(aCircle vector.Circle, aVelocity vector.Vec2, bCircl
 func PointLineDistance(v1, v2, p vector.Vec2) float64 {
 	return math.Abs((v2.X-v1.X)*(v1.Y-p.Y)-(v1.X-p.X)*(v2.Y-v1.Y)) / math.Sqrt(math.Pow(v2.X-v1.X, 2)+math.Pow(v2.Y-v1.Y, 2))
 }
+
+// returns the point inside the rectangle closest to p, p itself if it lies within the rectangle
+func ClosestPointInRectangle(p vector.Vec2, r vector.Rectangle) vector.Vec2 {
+	return vector.Vec2{
+		X: math.Max(r.Min.X, math.Min(p.X, r.Max.X)),
+		Y: math.Max(r.Min.Y, math.Min(p.Y, r.Max.Y)),
+	}
+}
+
+// calculates by how much a circle has to be moved to no longer overlap the rectangle, nil if they don't overlap
+func CircleRectangleDisplacement(c vector.Circle, r vector.Rectangle) *vector.Vec2 {
+	closest := ClosestPointInRectangle(c.Position, r)
+	if closest != c.Position {
+		distance := Distance(c.Position, closest)
+		if distance >= c.Radius {
+			return nil
+		}
+		displacement := c.Position.ToPoint(closest).WithLength(c.Radius - distance)
+		return &displacement
+	}
+
+	// the center is inside the rectangle, push it out through the closest side
+	left := c.Position.X - r.Min.X
+	right := r.Max.X - c.Position.X
+	top := c.Position.Y - r.Min.Y
+	bottom := r.Max.Y - c.Position.Y
+	switch math.Min(math.Min(left, right), math.Min(top, bottom)) {
+	case left:
+		return &vector.Vec2{X: -(left + c.Radius)}
+	case right:
+		return &vector.Vec2{X: right + c.Radius}
+	case top:
+		return &vector.Vec2{Y: -(top + c.Radius)}
+	default:
+		return &vector.Vec2{Y: bottom + c.Radius}
+	}
+}
@@ -169,3 +169,60 @@ func TestPointLineDistance(t *testing.T) {
 	}
 
 }
+
+func TestCircleRectangleDisplacement(t *testing.T) {
+	rect := vector.Rect(0, 0, 10, 10)
+	var tests = []struct {
+		name string
+		c    vector.Circle
+		want *vector.Vec2
+	}{
+		{
+			"no overlap",
+			vector.Circle{vector.Vec2{15, 5}, 2},
+			nil,
+		},
+		{
+			"touching",
+			vector.Circle{vector.Vec2{12, 5}, 2},
+			nil,
+		},
+		{
+			"overlapping right side",
+			vector.Circle{vector.Vec2{11, 5}, 2},
+			&vector.Vec2{1, 0},
+		},
+		{
+			"overlapping top side",
+			vector.Circle{vector.Vec2{5, -1}, 2},
+			&vector.Vec2{0, -1},
+		},
+		{
+			"center inside near left side",
+			vector.Circle{vector.Vec2{1, 5}, 2},
+			&vector.Vec2{-3, 0},
+		},
+		{
+			"center inside near bottom side",
+			vector.Circle{vector.Vec2{5, 9}, 2},
+			&vector.Vec2{0, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CircleRectangleDisplacement(tt.c, rect)
+			if result == nil && tt.want != nil {
+				t.Errorf("got nil, want %s", tt.want)
+			}
+			if result != nil && tt.want == nil {
+				t.Errorf("got %s, want nil", result)
+			}
+			if result != nil && tt.want != nil {
+				if *result != *tt.want {
+					t.Errorf("got %s, want %s", result, tt.want)
+				}
+			}
+		})
+	}
+}
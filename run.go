@@ -1,36 +1,174 @@
 package main
 
 import (
+	"fmt"
 	"image"
-	"math/rand"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/gentoomaniac/ebitmx"
+	"github.com/gentoomaniac/go-arena/arena"
+	"github.com/gentoomaniac/go-arena/entities"
+	"github.com/gentoomaniac/go-arena/vector"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/rs/zerolog/log"
 )
 
-const mapPath = "maps/test.tmx"
 const (
-	screenWidth   = 965
-	screenHeight  = 965
-	scalingFactor = .15
+	screenWidth  = 965
+	screenHeight = 965
 )
 
-func run(bots []string) {
-	tmxMap, error := ebitmx.LoadFromFile(mapPath)
+// newArenaMap extracts the simulation relevant parts of a tmx map
+func newArenaMap(tmxMap *ebitmx.TmxMap) (*arena.Map, error) {
+	spawnPoints := tmxMap.GetObjectGroupByName("spawn_points")
+	if spawnPoints == nil {
+		return nil, fmt.Errorf("map has no 'spawn_points' object group")
+	}
+
+	m := &arena.Map{
+		Width:  tmxMap.PixelWidth,
+		Height: tmxMap.PixelHeight,
+	}
+	if collisionMap := tmxMap.GetObjectGroupByName("collisionmap"); collisionMap != nil {
+		for _, object := range collisionMap.Objects {
+			m.Obstacles = append(m.Obstacles, arena.Obstacle{
+				Name: object.Name,
+				Box:  vector.Rect(float64(object.X), float64(object.Y), float64(object.X+object.Width), float64(object.Y+object.Height)),
+			})
+		}
+	}
+	for _, spawnPoint := range spawnPoints.Objects {
+		m.SpawnPoints = append(m.SpawnPoints, vector.Vec2{X: float64(spawnPoint.X), Y: float64(spawnPoint.Y)})
+	}
+	// the flag objects are named after their team
+	if flags := tmxMap.GetObjectGroupByName("flags"); flags != nil {
+		for _, flag := range flags.Objects {
+			m.Flags = append(m.Flags, arena.Flag{Team: flag.Name, Position: vector.Vec2{X: float64(flag.X), Y: float64(flag.Y)}})
+		}
+	}
+	if zones := tmxMap.GetObjectGroupByName("zones"); zones != nil {
+		for _, zone := range zones.Objects {
+			m.Zones = append(m.Zones, arena.Zone{
+				Name: zone.Name,
+				Box:  vector.Rect(float64(zone.X), float64(zone.Y), float64(zone.X+zone.Width), float64(zone.Y+zone.Height)),
+			})
+		}
+	}
+	if safeZone := tmxMap.GetObjectGroupByName("safe_zone"); safeZone != nil && len(safeZone.Objects) > 0 {
+		m.SafeZoneCenter = &vector.Vec2{X: float64(safeZone.Objects[0].X), Y: float64(safeZone.Objects[0].Y)}
+	}
+	return m, nil
+}
+
+// newGameMode creates the game mode selected with --mode
+func newGameMode(name string, m *arena.Map, teams []string, scoreLimit int, tickLimit int64) (arena.GameMode, error) {
+	switch name {
+	case "dm":
+		return arena.NewDeathmatch(scoreLimit), nil
+	case "timed":
+		return arena.NewTimedScore(tickLimit), nil
+	case "ctf":
+		ctf, err := arena.NewCaptureTheFlag(m, scoreLimit)
+		if err != nil {
+			return nil, err
+		}
+		return ctf, ctf.CheckTeams(teams)
+	case "koth":
+		return arena.NewKingOfTheHill(m, scoreLimit, tickLimit)
+	case "zone":
+		return arena.NewSafeZone(m, tickLimit), nil
+	default:
+		return &arena.LastManStanding{}, nil
+	}
+}
+
+// loadArenaMap loads the map for a match and makes sure it fits the number of players
+func loadArenaMap(tmxMap *ebitmx.TmxMap, players int) (*arena.Map, error) {
+	m, err := newArenaMap(tmxMap)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Validate(players); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// scalingFactor fits the whole map into the window
+func scalingFactor(tmxMap *ebitmx.TmxMap) float64 {
+	return math.Min(float64(screenWidth)/float64(tmxMap.PixelWidth), float64(screenHeight)/float64(tmxMap.PixelHeight))
+}
+
+// loadRules returns the rules from --config or the defaults
+func loadRules() arena.Rules {
+	if cli.Config == "" {
+		return arena.DefaultRules()
+	}
+
+	rules, err := arena.LoadRules(cli.Config)
+	if err != nil {
+		log.Fatal().Err(err).Str("file", cli.Config).Msg("could not load rules")
+	}
+	return rules
+}
+
+// parseBots splits bot arguments like "team1:bots/testbot/testbot.so" into teams and paths
+func parseBots(args []string) (teams []string, paths []string) {
+	for _, arg := range args {
+		team, path := "", arg
+		if i := strings.Index(arg, ":"); i > 0 {
+			team, path = arg[:i], arg[i+1:]
+		}
+		teams = append(teams, team)
+		paths = append(paths, path)
+	}
+	return teams, paths
+}
+
+func loadBots(paths []string) ([]arena.BotFactory, error) {
+	var factories []arena.BotFactory
+	for _, path := range paths {
+		factory, err := arena.LoadBot(path)
+		if err != nil {
+			return nil, err
+		}
+		factories = append(factories, factory)
+	}
+	return factories, nil
+}
+
+// startBots creates an instance of every bot for a single match
+func startBots(factories []arena.BotFactory) ([]entities.AI, error) {
+	var bots []entities.AI
+	for _, factory := range factories {
+		ai, err := factory()
+		if err != nil {
+			arena.CloseBots(bots)
+			return nil, err
+		}
+		bots = append(bots, ai)
+	}
+	return bots, nil
+}
+
+func loadMap(path string) *ebitmx.TmxMap {
+	tmxMap, error := ebitmx.LoadFromFile(path)
 	if error != nil {
 		log.Fatal().Err(error).Msg("")
 	}
 
 	startPosition := image.Point{0, 0}
 
-	tmxMap.CameraBounds = image.Rect(0, 0, 6400, 6400)
+	tmxMap.CameraBounds = image.Rect(0, 0, tmxMap.PixelWidth, tmxMap.PixelHeight)
 	tmxMap.CameraPosition = startPosition
 	log.Debug().Int("width", tmxMap.PixelWidth).Int("height", tmxMap.PixelHeight).Msg("map dimensions")
 
-	rand.Seed(time.Now().UTC().UnixNano())
-	game := NewGame().WithMap(tmxMap).WithScalingFactor(scalingFactor).WithRespawns(cli.Respawns).WithBots(bots)
+	return tmxMap
+}
+
+func runGame(game *Game) {
 	err := game.Init()
 	if err != nil {
 		log.Error().Err(err).Msg("initialising game failed")
@@ -43,3 +181,103 @@ func run(bots []string) {
 		log.Fatal().Err(err).Msg("")
 	}
 }
+
+func run(botArgs []string) {
+	teams, botPaths := parseBots(botArgs)
+
+	tmxMap := loadMap(cli.Run.Map)
+	arenaMap, err := loadArenaMap(tmxMap, len(botPaths))
+	if err != nil {
+		log.Error().Err(err).Str("map", cli.Run.Map).Msg("invalid map")
+		return
+	}
+
+	// the world's tick limit would end timed, king of the hill and safe zone matches early
+	usesTickLimit := cli.Run.Mode == "timed" || cli.Run.Mode == "koth" || cli.Run.Mode == "zone"
+	if usesTickLimit && cli.Run.MaxTicks > 0 && cli.Run.TickLimit > cli.Run.MaxTicks {
+		log.Error().Int64("tick-limit", cli.Run.TickLimit).Int64("max-ticks", cli.Run.MaxTicks).Msg("--tick-limit is longer than --max-ticks, raise --max-ticks or set it to 0")
+		return
+	}
+
+	mode, err := newGameMode(cli.Run.Mode, arenaMap, teams, cli.Run.ScoreLimit, cli.Run.TickLimit)
+	if err != nil {
+		log.Error().Err(err).Str("mode", cli.Run.Mode).Msg("invalid game mode")
+		return
+	}
+
+	factories, err := loadBots(botPaths)
+	if err != nil {
+		log.Error().Err(err).Msg("loading bots failed")
+		return
+	}
+	bots, err := startBots(factories)
+	if err != nil {
+		log.Error().Err(err).Msg("starting bots failed")
+		return
+	}
+	defer arena.CloseBots(bots)
+
+	seed := cli.Run.Seed
+	if seed == 0 {
+		seed = time.Now().UTC().UnixNano()
+	}
+	log.Info().Int64("seed", seed).Msg("starting match")
+
+	world := arena.NewWorld(arenaMap).
+		WithRules(loadRules()).
+		WithMode(mode).
+		WithSeed(seed).
+		WithRespawns(cli.Run.Respawns).
+		WithComputeBudget(cli.Run.ComputeBudget, cli.Run.MaxOverruns).
+		WithTickLimit(cli.Run.MaxTicks, cli.Run.SuddenDeath).
+		WithTeams(teams).
+		WithBots(bots)
+	game := NewGame().WithMap(tmxMap).WithWorld(world).WithScalingFactor(scalingFactor(tmxMap))
+
+	var recorder *arena.Recorder
+	if cli.Run.Record != "" {
+		recorder = arena.NewRecorder(world, cli.Run.Map)
+		game.WithRecorder(recorder)
+	}
+
+	runGame(game)
+
+	if world.GameOver {
+		log.Info().Int64("tick", world.Tick).Str("reason", string(world.Reason)).Msg("match over")
+	}
+	if team := world.WinningTeam(); team != "" {
+		log.Info().Str("team", team).Msg("team won the match")
+	} else if winner := world.Winner(); winner != nil {
+		log.Info().Str("winner", winner.Name).Msg("match won")
+	}
+	for _, score := range world.Mode.Scoreboard(world) {
+		log.Info().Str("name", score.Name).Int("points", score.Points).Msg("score")
+	}
+
+	if recorder != nil {
+		if err := recorder.Replay().Save(cli.Run.Record); err != nil {
+			log.Error().Err(err).Msg("could not write replay")
+			return
+		}
+		log.Info().Str("file", cli.Run.Record).Int("frames", len(recorder.Replay().Frames)).Msg("replay written")
+	}
+}
+
+func replay(path string) {
+	r, err := arena.LoadReplay(path)
+	if err != nil {
+		log.Error().Err(err).Msg("could not load replay")
+		return
+	}
+
+	tmxMap := loadMap(r.MapPath)
+	arenaMap, err := newArenaMap(tmxMap)
+	if err != nil {
+		log.Error().Err(err).Str("map", r.MapPath).Msg("invalid map")
+		return
+	}
+	playback := arena.NewPlayback(r, arenaMap)
+	log.Info().Int64("seed", r.Seed).Int("frames", len(r.Frames)).Msg("starting replay")
+
+	runGame(NewGame().WithMap(tmxMap).WithPlayback(playback).WithScalingFactor(scalingFactor(tmxMap)))
+}
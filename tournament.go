@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gentoomaniac/go-arena/arena"
+	"github.com/rs/zerolog/log"
+)
+
+func tournament(botPaths []string) {
+	tmxMap := loadMap(cli.Tournament.Map)
+	arenaMap, err := newArenaMap(tmxMap)
+	if err != nil {
+		log.Error().Err(err).Str("map", cli.Tournament.Map).Msg("invalid map")
+		return
+	}
+
+	bots, err := loadBots(botPaths)
+	if err != nil {
+		log.Error().Err(err).Msg("loading bots failed")
+		return
+	}
+
+	seed := cli.Tournament.Seed
+	if seed == 0 {
+		seed = time.Now().UTC().UnixNano()
+	}
+	log.Info().Int64("seed", seed).Msg("starting tournament")
+
+	t := arena.NewTournament(arenaMap).
+		WithRules(loadRules()).
+		WithRounds(cli.Tournament.Rounds).
+		WithFormat(arena.Format(cli.Tournament.Format)).
+		WithMaxTicks(cli.Tournament.MaxTicks).
+		WithSuddenDeath(cli.Tournament.SuddenDeath).
+		WithRespawns(cli.Tournament.Respawns).
+		WithComputeBudget(cli.Tournament.ComputeBudget, cli.Tournament.MaxOverruns).
+		WithSeed(seed)
+	for i, bot := range bots {
+		t.AddBot(strings.TrimSuffix(filepath.Base(botPaths[i]), filepath.Ext(botPaths[i])), bot)
+	}
+
+	standings, err := t.Run()
+	if err != nil {
+		log.Error().Err(err).Str("map", cli.Tournament.Map).Msg("tournament failed")
+		return
+	}
+	printStandings(standings)
+}
+
+func printStandings(standings []*arena.Standing) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tBot\tMatches\tWins\tKills\tDeaths\tAvg. survival (ticks)")
+	for i, s := range standings {
+		survival := int64(0)
+		if s.Matches > 0 {
+			survival = s.TicksAlive / int64(s.Matches)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\t%d\t%d\n", i+1, s.Name, s.Matches, s.Wins, s.Kills, s.Deaths, survival)
+	}
+	w.Flush()
+}
@@ -40,6 +40,20 @@ type Stats struct {
 	headline *Text
 	cache    *ebiten.Image
 	players  []*entities.Player
+	score    func(*entities.Player) int
+	status   func() string
+}
+
+// WithScore adds the score of every player's team to the stats
+func (s *Stats) WithScore(score func(*entities.Player) int) *Stats {
+	s.score = score
+	return s
+}
+
+// WithStatus adds a line about the state of the match below the players, it's left out when empty
+func (s *Stats) WithStatus(status func() string) *Stats {
+	s.status = status
+	return s
 }
 
 func (s *Stats) Image(refresh bool) *ebiten.Image {
@@ -61,10 +75,38 @@ func (s *Stats) Image(refresh bool) *ebiten.Image {
 		op.GeoM.Scale(TextScaling, TextScaling)
 		op.GeoM.Translate(MarginLeft, MarginTop+float64(headlineImg.Bounds().Dy())*HeadlineScaling+Spacer)
 		for index, p := range s.players {
-			text := NewText(fmt.Sprintf("#%d %s %d", index+1, p.Name, p.Health))
+			name := p.Name
+			if p.Team != "" {
+				name = fmt.Sprintf("[%s] %s", p.Team, p.Name)
+			}
+			line := fmt.Sprintf("#%d %s H %d E %.0f", index+1, name, p.Health, p.Energy)
+			if s.score != nil {
+				line = fmt.Sprintf("%s S %s", line, signed(s.score(p)))
+			}
+			switch p.State {
+			case entities.Crashed:
+				line = fmt.Sprintf("#%d %s crashed in tick %d", index+1, name, p.CrashTick)
+			case entities.Disqualified:
+				line = fmt.Sprintf("#%d %s disqualified", index+1, name)
+			}
+			text := NewText(line)
 			s.cache.DrawImage(text.Image(false), op)
 			op.GeoM.Translate(0, float64(text.image.Bounds().Dy())*TextScaling+Spacer)
 		}
+		if s.status != nil {
+			if status := s.status(); status != "" {
+				op.GeoM.Translate(0, Spacer)
+				s.cache.DrawImage(NewText(status).Image(false), op)
+			}
+		}
 	}
 	return s.cache
 }
+
+// signed spells out the sign of negative numbers, the font has no glyph for it
+func signed(n int) string {
+	if n < 0 {
+		return fmt.Sprintf("minus %d", -n)
+	}
+	return fmt.Sprint(n)
+}
@@ -3,8 +3,8 @@ package vector
 import "fmt"
 
 type Rectangle struct {
-	Min Vec2
-	Max Vec2
+	Min Vec2 `json:"min"`
+	Max Vec2 `json:"max"`
 }
 
 func (c Rectangle) String() string {
@@ -6,8 +6,8 @@ import (
 )
 
 type Vec2 struct {
-	X float64
-	Y float64
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
 }
 
 func (v Vec2) String() string {